@@ -7,37 +7,33 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	logger "sixTask/config/looger"
+	"sixTask/config/bootstrap"
 	"sixTask/config/scheduler"
 	"syscall"
 	"time"
 
-	"github.com/joho/godotenv"
-
 	"sixTask/database/seeds"
 	"sixTask/routes"
 )
 
 func main() {
-	logger.SetupLogger()
-
-	if err := godotenv.Load(); err != nil {
-		fmt.Println("No .env file found")
+	// Carrega a configuração, os logs e os provedores compartilhados; configuração inválida impede a inicialização
+	cfg, shutdown, err := bootstrap.Setup(context.Background(), "app")
+	if err != nil {
+		log.Fatalf("Erro ao iniciar a aplicação: %v", err)
 	}
+	defer shutdown()
 
 	seeds.Run()
 
 	// Inicializa o scheduler
 	scheduler.SetupScheduler()
 
-	// Inicializa o worker
-	//workerConfig.SetupWorker()
-
 	router := routes.SetupRoutes()
 
 	// Cria um servidor HTTP com configurações personalizadas
 	srv := &http.Server{
-		Addr:    ":3030",
+		Addr:    fmt.Sprintf(":%d", cfg.App.Port),
 		Handler: router,
 	}
 
@@ -1,42 +1,31 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"github.com/joho/godotenv"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
-	logger "sixTask/c
+	"sixTask/config/bootstrap"
+	"sixTask/config/worker"
+	"sixTask/internal/jobs"
+	"sixTask/routes"
 	"syscall"
 	"time"
-
-	"github.com/hibiken/asynq"
 )
 
 func main() {
-
-	if err := godotenv.Load(); err != nil {
-		fmt.Println("No .env file found")
+	// Carrega a configuração, os logs e os provedores usados pelos jobs; configuração inválida impede a inicialização
+	cfg, shutdown, err := bootstrap.Setup(context.Background(), "worker")
+	if err != nil {
+		log.Fatalf("Erro ao iniciar o worker: %v", err)
 	}
+	defer shutdown()
 
-	logger.SetupLogger()
-
-	client := asynq.NewClient(asynq.RedisClientOpt{Addr: "localhost:6379"})
-	defer client.Close()
-
-	srv := asynq.NewServer(
-		asynq.RedisClientOpt{Addr: "localhost:6379"},
-		asynq.Config{
-			Concurrency: 10,
-			Queues: map[string]int{
-				"planilhas": 6,
-				"default":   2,
-			},
-		},
-	)
-
-	mux := asynq.NewServeMux()
-	mux.HandleFunc(jobs.JobName, jobs.Execute())
+	registry := jobs.Registry()
+	srv := worker.NewServer(registry)
+	mux := worker.NewServeMux(registry)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -48,6 +37,17 @@ func main() {
 		}
 	}()
 
+	// Expõe /healthz, /readyz e /metrics para o orquestrador e o Prometheus
+	probes := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.App.WorkerPort),
+		Handler: routes.SetupWorkerRoutes(),
+	}
+	go func() {
+		if err := probes.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Erro ao iniciar o servidor de métricas do worker: %v", err)
+		}
+	}()
+
 	log.Println("Worker iniciado. Pressione Ctrl+C para sair.")
 
 	// Espera Ctrl+C
@@ -55,6 +55,13 @@ func main() {
 
 	log.Println("Finalizando aplicação...")
 
+	// Para de responder às sondas antes de encerrar o worker, tirando-o do balanceamento
+	probeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := probes.Shutdown(probeCtx); err != nil {
+		log.Printf("Erro ao encerrar o servidor de métricas do worker: %v", err)
+	}
+
 	// Cria um canal para sinalizar que o worker foi encerrado
 	done := make(chan struct{})
 
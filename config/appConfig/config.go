@@ -0,0 +1,208 @@
+package appConfig
+
+import (
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Config reúne todas as configurações da aplicação. É carregada e validada uma única vez na
+// inicialização (ver Load), registrada com Set e lida pelos subsistemas com Get; nenhum outro
+// pacote lê o ambiente.
+type Config struct {
+	App      AppConfig
+	Log      LogConfig
+	Auth     AuthConfig
+	Database DatabaseConfig
+	Redis    RedisConfig
+	Mail     MailConfig
+	Storage  StorageConfig
+	Upload   UploadConfig
+	Scanner  ScannerConfig
+	Import   ImportConfig
+	Metrics  MetricsConfig
+	Tracing  TracingConfig
+}
+
+// AppConfig contém os dados gerais da aplicação
+type AppConfig struct {
+	Name string
+	Env  string
+	// Port é a porta em que o servidor HTTP escuta (SERVER_PORT). APP_PORT é usada
+	// apenas pelo docker-compose para publicar essa porta no host.
+	Port int
+	// WorkerPort é a porta em que o worker expõe /healthz, /readyz e /metrics
+	WorkerPort int
+	// URL é o endereço do frontend usado nos links enviados por email e notificações
+	URL string
+}
+
+// LogConfig contém o nível, o formato e a rotação dos logs
+type LogConfig struct {
+	// Level é debug, info, warn ou error
+	Level string
+	// Format é json ou text
+	Format string
+	Dir    string
+	// Stdout também escreve os logs na saída padrão, além do arquivo
+	Stdout bool
+	// MaxSize é o tamanho a partir do qual o arquivo do dia é rotacionado
+	MaxSize int64
+	// MaxDays é por quantos dias os arquivos rotacionados são mantidos
+	MaxDays int
+}
+
+// MetricsConfig contém o acesso à rota /metrics
+type MetricsConfig struct {
+	// Token, quando definido, é exigido como Authorization: Bearer <token>
+	Token string
+}
+
+// TracingConfig contém a exportação dos traces do OpenTelemetry
+type TracingConfig struct {
+	// Exporter é none, otlp ou stdout
+	Exporter string
+	// Endpoint é a URL do coletor OTLP/HTTP (ex.: http://localhost:4318)
+	Endpoint string
+	// SampleRatio é a fração dos traces iniciados aqui que é registrada, entre 0 e 1
+	SampleRatio float64
+}
+
+// AuthConfig contém o segredo de assinatura e a validade dos tokens
+type AuthConfig struct {
+	Secret               string
+	AccessTokenTTL       time.Duration
+	RefreshTokenTTL      time.Duration
+	PasswordResetTTL     time.Duration
+	EmailVerificationTTL time.Duration
+}
+
+// DatabaseConfig contém a conexão e o pool do PostgreSQL
+type DatabaseConfig struct {
+	Host              string
+	Port              string
+	Username          string
+	Password          string
+	Database          string
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	ConnectTimeout    time.Duration
+	// SlowQueryThreshold é a duração a partir da qual uma consulta é registrada como lenta
+	SlowQueryThreshold time.Duration
+}
+
+// ConnString monta a string de conexão do PostgreSQL, escapando usuário e senha
+func (d DatabaseConfig) ConnString() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(d.Username, d.Password),
+		Host:   net.JoinHostPort(d.Host, d.Port),
+		Path:   "/" + d.Database,
+	}
+
+	return u.String()
+}
+
+// RedisConfig contém a conexão com o Redis usado pelas filas, worker e scheduler
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// MailConfig contém o transporte e o remetente dos emails
+type MailConfig struct {
+	// Mailer é o transporte: smtp, log (ou file) e memory
+	Mailer string
+	// LogPath é o prefixo do storage onde o transporte log grava as mensagens
+	LogPath     string
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	FromName    string
+	FromAddress string
+	Locale      string
+}
+
+// StorageConfig contém o driver de armazenamento de arquivos
+type StorageConfig struct {
+	// Driver é local ou s3
+	Driver    string
+	LocalPath string
+	PublicURL string
+	URLTTL    time.Duration
+	S3        S3Config
+}
+
+// S3Config contém o bucket compatível com S3 (AWS, MinIO, etc.)
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Region    string
+	UseSSL    bool
+	PublicURL string
+	URLTTL    time.Duration
+}
+
+// UploadConfig contém os limites de upload, as cotas de armazenamento e a validade dos links de anexos
+type UploadConfig struct {
+	MaxRequestSize           int64
+	MaxSize                  int64
+	AllowedTypes             []string
+	AttachmentMaxSizeProject int64
+	AttachmentMaxSizeTask    int64
+	UserQuota                int64
+	ProjectQuota             int64
+	ChunkMaxSize             int64
+	SessionTTL               time.Duration
+	AttachmentURLTTL         time.Duration
+}
+
+// ScannerConfig contém a verificação antivírus dos anexos
+type ScannerConfig struct {
+	// Driver é none, clamav ou fake
+	Driver        string
+	ClamAVAddress string
+	ClamAVTimeout time.Duration
+}
+
+// ImportConfig contém os limites da importação de planilhas
+type ImportConfig struct {
+	MaxSize       int64
+	BatchSize     int
+	DryRunRows    int
+	AllowedTables []string
+}
+
+var (
+	configMu sync.Mutex
+	config   *Config
+)
+
+// Get retorna a configuração em uso. Entra em pânico se Set ainda não foi chamado: a configuração
+// só pode ser usada depois de carregada e validada (ver bootstrap.Setup), nunca com valores padrão.
+func Get() *Config {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if config == nil {
+		panic("appConfig: configuração usada antes de appConfig.Set (ver bootstrap.Setup)")
+	}
+
+	return config
+}
+
+// Set define a configuração usada por todos os subsistemas
+func Set(cfg *Config) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	config = cfg
+}
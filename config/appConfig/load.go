@@ -0,0 +1,310 @@
+package appConfig
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Load lê a configuração da aplicação e a valida. As variáveis já definidas no ambiente
+// têm prioridade sobre as do arquivo, que é o indicado em CONFIG_FILE ou, se ausente, o .env
+// (opcional). Valores inválidos ou obrigatórios ausentes impedem a inicialização.
+func Load() (*Config, error) {
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		if err := godotenv.Load(file); err != nil {
+			return nil, fmt.Errorf("erro ao ler o arquivo de configuração %s: %w", file, err)
+		}
+	} else if err := godotenv.Load(); err != nil {
+		log.Println("Arquivo .env não encontrado, usando apenas as variáveis de ambiente")
+	}
+
+	cfg, err := FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("configuração inválida: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// FromEnv monta a configuração a partir das variáveis de ambiente, aplicando os valores
+// padrão. A configuração é sempre retornada; o erro lista as variáveis com valores inválidos.
+func FromEnv() (*Config, error) {
+	e := &env{}
+
+	appEnv := e.string("APP_ENV", "local")
+	// Em desenvolvimento o texto é mais legível; nos demais ambientes o JSON facilita a coleta
+	logFormat := "json"
+	if appEnv == "local" {
+		logFormat = "text"
+	}
+
+	cfg := &Config{
+		App: AppConfig{
+			Name:       e.string("APP_NAME", "sixTask"),
+			Env:        appEnv,
+			Port:       e.int("SERVER_PORT", 3030),
+			WorkerPort: e.int("WORKER_PORT", 3031),
+			URL:        strings.TrimRight(e.string("APP_URL", "http://localhost:8080"), "/"),
+		},
+		Log: LogConfig{
+			Level:   strings.ToLower(e.string("LOG_LEVEL", "info")),
+			Format:  strings.ToLower(e.string("LOG_FORMAT", logFormat)),
+			Dir:     e.string("LOG_DIR", "storage/log"),
+			Stdout:  e.bool("LOG_STDOUT", true),
+			MaxSize: e.size("LOG_MAX_SIZE", 100<<20),
+			MaxDays: e.int("LOG_MAX_DAYS", 15),
+		},
+		Auth: AuthConfig{
+			Secret:               os.Getenv("SECRET"),
+			AccessTokenTTL:       e.duration("JWT_ACCESS_TTL", 15*time.Minute),
+			RefreshTokenTTL:      e.duration("JWT_REFRESH_TTL", 7*24*time.Hour),
+			PasswordResetTTL:     e.duration("PASSWORD_RESET_TTL", time.Hour),
+			EmailVerificationTTL: e.duration("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+		},
+		Database: DatabaseConfig{
+			Host:               e.string("DB_HOST", "localhost"),
+			Port:               e.string("DB_PORT", "5432"),
+			Username:           os.Getenv("DB_USERNAME"),
+			Password:           os.Getenv("DB_PASSWORD"),
+			Database:           os.Getenv("DB_DATABASE"),
+			MaxConns:           int32(e.int("DB_MAX_CONNS", 10)),
+			MinConns:           int32(e.int("DB_MIN_CONNS", 2)),
+			MaxConnLifetime:    e.duration("DB_MAX_CONN_LIFETIME", time.Hour),
+			MaxConnIdleTime:    e.duration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute),
+			HealthCheckPeriod:  e.duration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+			ConnectTimeout:     e.duration("DB_CONNECT_TIMEOUT", 5*time.Second),
+			SlowQueryThreshold: e.duration("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+		},
+		Redis: RedisConfig{
+			Addr:     e.string("REDIS_ADDR", "localhost:6379"),
+			Password: os.Getenv("REDIS_PASSWORD"),
+			DB:       e.int("REDIS_DB", 0),
+		},
+		Mail: MailConfig{
+			Mailer:      strings.ToLower(e.string("MAIL_MAILER", "smtp")),
+			LogPath:     e.string("MAIL_LOG_PATH", "mail"),
+			Host:        os.Getenv("MAIL_HOST"),
+			Port:        e.int("MAIL_PORT", 0),
+			Username:    os.Getenv("MAIL_USERNAME"),
+			Password:    os.Getenv("MAIL_PASSWORD"),
+			FromName:    os.Getenv("MAIL_FROM_NAME"),
+			FromAddress: os.Getenv("MAIL_FROM_ADDRESS"),
+			Locale:      os.Getenv("MAIL_LOCALE"),
+		},
+		Storage: StorageConfig{
+			Driver:    strings.ToLower(e.string("STORAGE_DRIVER", "local")),
+			LocalPath: e.string("STORAGE_LOCAL_PATH", "storage/app"),
+			PublicURL: e.string("STORAGE_PUBLIC_URL", "/storage"),
+			URLTTL:    e.duration("STORAGE_URL_TTL", 15*time.Minute),
+			S3: S3Config{
+				Endpoint:  os.Getenv("S3_ENDPOINT"),
+				Bucket:    os.Getenv("S3_BUCKET"),
+				AccessKey: os.Getenv("S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("S3_SECRET_KEY"),
+				Region:    os.Getenv("S3_REGION"),
+				UseSSL:    e.bool("S3_USE_SSL", true),
+				PublicURL: os.Getenv("S3_PUBLIC_URL"),
+				URLTTL:    e.duration("S3_URL_TTL", 15*time.Minute),
+			},
+		},
+		Upload: UploadConfig{
+			MaxRequestSize: e.size("UPLOAD_MAX_REQUEST_SIZE", 110<<20),
+			MaxSize:        e.size("UPLOAD_MAX_SIZE", 20<<20),
+			AllowedTypes: e.list("UPLOAD_ALLOWED_TYPES", []string{
+				"image/*",
+				"application/pdf",
+				"text/plain",
+				"text/csv",
+				"application/zip",
+				"application/vnd.openxmlformats-officedocument.*",
+				"application/vnd.ms-excel",
+				"application/msword",
+			}),
+			AttachmentMaxSizeProject: e.size("ATTACHMENT_MAX_SIZE_PROJECT", 100<<20),
+			AttachmentMaxSizeTask:    e.size("ATTACHMENT_MAX_SIZE_TASK", 25<<20),
+			UserQuota:                e.size("USER_STORAGE_QUOTA", 1<<30),
+			ProjectQuota:             e.size("PROJECT_STORAGE_QUOTA", 5<<30),
+			ChunkMaxSize:             e.size("UPLOAD_CHUNK_MAX_SIZE", 16<<20),
+			SessionTTL:               e.duration("UPLOAD_SESSION_TTL", 24*time.Hour),
+			AttachmentURLTTL:         e.duration("ATTACHMENT_URL_TTL", 15*time.Minute),
+		},
+		Scanner: ScannerConfig{
+			Driver:        strings.ToLower(e.string("SCANNER_DRIVER", "none")),
+			ClamAVAddress: e.string("CLAMAV_ADDRESS", "tcp://localhost:3310"),
+			ClamAVTimeout: e.duration("CLAMAV_TIMEOUT", time.Minute),
+		},
+		Import: ImportConfig{
+			MaxSize:       e.size("IMPORT_MAX_SIZE", 50<<20),
+			BatchSize:     e.int("IMPORT_BATCH_SIZE", 1000),
+			DryRunRows:    e.int("IMPORT_DRY_RUN_ROWS", 1000),
+			AllowedTables: e.list("IMPORT_ALLOWED_TABLES", []string{"shipments"}),
+		},
+		Metrics: MetricsConfig{
+			Token: os.Getenv("METRICS_TOKEN"),
+		},
+		Tracing: TracingConfig{
+			Exporter:    strings.ToLower(e.string("OTEL_TRACES_EXPORTER", "none")),
+			Endpoint:    e.string("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+			SampleRatio: e.float("OTEL_TRACES_SAMPLER_ARG", 1),
+		},
+	}
+
+	return cfg, errors.Join(e.errs...)
+}
+
+// env lê as variáveis de ambiente acumulando os valores inválidos, de modo que
+// todos sejam informados de uma vez na inicialização
+type env struct {
+	errs []error
+}
+
+// lookup retorna o valor da variável, sem espaços nas pontas; vazio indica ausente
+func (e *env) lookup(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+// invalid registra um valor inválido
+func (e *env) invalid(key, value, reason string) {
+	e.errs = append(e.errs, fmt.Errorf("%s inválido (%q): %s", key, value, reason))
+}
+
+func (e *env) string(key, fallback string) string {
+	if value := e.lookup(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func (e *env) int(key string, fallback int) int {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		e.invalid(key, value, "informe um número inteiro")
+		return fallback
+	}
+
+	return n
+}
+
+func (e *env) bool(key string, fallback bool) bool {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		e.invalid(key, value, "informe true ou false")
+		return fallback
+	}
+
+	return b
+}
+
+func (e *env) float(key string, fallback float64) float64 {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.invalid(key, value, "informe um número, como 0.25")
+		return fallback
+	}
+
+	return f
+}
+
+// duration lê uma duração positiva (ex.: "30s", "5m", "168h")
+func (e *env) duration(key string, fallback time.Duration) time.Duration {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		e.invalid(key, value, "informe uma duração positiva, como 30s, 15m ou 24h")
+		return fallback
+	}
+
+	return d
+}
+
+// size lê um tamanho positivo em bytes, aceitando os sufixos KB, MB, GB e TB (ex.: "512MB")
+func (e *env) size(key string, fallback int64) int64 {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	size, err := ParseSize(value)
+	if err != nil || size <= 0 {
+		e.invalid(key, value, "informe um tamanho positivo, como 512KB, 20MB ou 1GB")
+		return fallback
+	}
+
+	return size
+}
+
+// list lê uma lista separada por vírgulas, ignorando itens vazios
+func (e *env) list(key string, fallback []string) []string {
+	value := e.lookup(key)
+	if value == "" {
+		return fallback
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// ParseSize converte um tamanho como "10MB" ou "1048576" em bytes
+func ParseSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		bytes  int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	} {
+		if number, ok := strings.CutSuffix(value, unit.suffix); ok {
+			value, multiplier = strings.TrimSpace(number), unit.bytes
+			break
+		}
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tamanho inválido: %q", value)
+	}
+
+	return number * multiplier, nil
+}
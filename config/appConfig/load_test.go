@@ -0,0 +1,44 @@
+package appConfig
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"1048576", 1048576, false},
+		{"512B", 512, false},
+		{"512KB", 512 << 10, false},
+		{"20MB", 20 << 20, false},
+		{"1GB", 1 << 30, false},
+		{"2TB", 2 << 40, false},
+		{"10mb", 10 << 20, false},
+		{" 10 MB ", 10 << 20, false},
+		{"0", 0, false},
+		{"", 0, true},
+		{"MB", 0, true},
+		{"1.5MB", 0, true},
+		{"10MiB", 0, true},
+		{"dez MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSize(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSize(%q) = %d, esperado erro", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSize(%q) erro inesperado: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, esperado %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package appConfig
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MaxAttachmentURLTTL é a validade máxima de um link assinado de anexo
+const MaxAttachmentURLTTL = 7 * 24 * time.Hour
+
+// Validate confere as combinações de valores que impediriam a aplicação de funcionar,
+// retornando todos os problemas encontrados de uma vez
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Auth.Secret != "", "SECRET é obrigatório: ele assina os tokens de acesso e os links de download")
+	check(c.App.Port > 0 && c.App.Port <= 65535, "SERVER_PORT inválida: %d", c.App.Port)
+	check(c.App.WorkerPort > 0 && c.App.WorkerPort <= 65535, "WORKER_PORT inválida: %d", c.App.WorkerPort)
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level),
+		"LOG_LEVEL inválido: %q (use debug, info, warn ou error)", c.Log.Level)
+	check(slices.Contains([]string{"json", "text"}, c.Log.Format), "LOG_FORMAT inválido: %q (use json ou text)", c.Log.Format)
+	check(c.Log.MaxDays > 0, "LOG_MAX_DAYS deve ser maior que zero")
+
+	check(c.Database.Host != "" && c.Database.Port != "", "DB_HOST e DB_PORT são obrigatórios")
+	check(c.Database.Database != "", "DB_DATABASE é obrigatório")
+	check(c.Database.MaxConns > 0, "DB_MAX_CONNS deve ser maior que zero")
+	check(c.Database.MinConns >= 0 && c.Database.MinConns <= c.Database.MaxConns, "DB_MIN_CONNS deve estar entre 0 e DB_MAX_CONNS")
+
+	check(c.Redis.Addr != "", "REDIS_ADDR é obrigatório")
+
+	check(slices.Contains([]string{"none", "otlp", "stdout"}, c.Tracing.Exporter),
+		"OTEL_TRACES_EXPORTER inválido: %q (use none, otlp ou stdout)", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "OTEL_EXPORTER_OTLP_ENDPOINT é obrigatório para OTEL_TRACES_EXPORTER=otlp")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "OTEL_TRACES_SAMPLER_ARG deve estar entre 0 e 1")
+
+	switch c.Mail.Mailer {
+	case "smtp":
+		check(c.Mail.Host != "", "MAIL_HOST é obrigatório para MAIL_MAILER=smtp")
+		check(c.Mail.Port > 0, "MAIL_PORT é obrigatória para MAIL_MAILER=smtp")
+	case "log", "file", "memory":
+	default:
+		check(false, "MAIL_MAILER inválido: %q (use smtp, log ou memory)", c.Mail.Mailer)
+	}
+
+	switch c.Storage.Driver {
+	case "local":
+	case "s3":
+		check(c.Storage.S3.Endpoint != "" && c.Storage.S3.Bucket != "", "S3_ENDPOINT e S3_BUCKET são obrigatórios para STORAGE_DRIVER=s3")
+	default:
+		check(false, "STORAGE_DRIVER inválido: %q (use local ou s3)", c.Storage.Driver)
+	}
+
+	check(slices.Contains([]string{"none", "clamav", "fake"}, c.Scanner.Driver),
+		"SCANNER_DRIVER inválido: %q (use none, clamav ou fake)", c.Scanner.Driver)
+
+	check(c.Upload.AttachmentURLTTL <= MaxAttachmentURLTTL, "ATTACHMENT_URL_TTL não pode passar de %s", MaxAttachmentURLTTL)
+	check(c.Import.BatchSize > 0, "IMPORT_BATCH_SIZE deve ser maior que zero")
+	check(c.Import.DryRunRows > 0, "IMPORT_DRY_RUN_ROWS deve ser maior que zero")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("configuração inválida: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
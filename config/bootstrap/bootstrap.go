@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/logger"
+	"sixTask/config/queue"
+	"sixTask/config/scannerProvider"
+	"sixTask/config/storageProvider"
+	"sixTask/config/tracing"
+	"sixTask/internal/database"
+)
+
+// Setup carrega e valida a configuração, disponibiliza-a aos subsistemas e cria os
+// provedores compartilhados pelo servidor e pelo worker (logs, traces, storage, antivírus
+// e o pool do banco), registrando as métricas do pool e das filas. O transporte de email é criado
+// pelo emailprovider apenas no primeiro envio. name identifica o processo
+// no arquivo de log (ex.: storage/log/worker-<data>.log) e nos traces (ex.: sixTask-worker).
+// Qualquer erro aqui deve impedir a inicialização do processo; a função retornada
+// encerra o pool e o cliente das filas, envia os spans pendentes e fecha o arquivo de log.
+func Setup(ctx context.Context, name string) (*appConfig.Config, func(), error) {
+	cfg, err := appConfig.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	appConfig.Set(cfg)
+
+	logFile, err := logger.Setup(cfg.Log, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao configurar os logs: %w", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.App.Name+"-"+name, cfg.App.Env)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao configurar os traces: %w", err)
+	}
+
+	store, err := storageProvider.New(cfg.Storage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao configurar o storage: %w", err)
+	}
+	storageProvider.SetDefault(store)
+
+	scanner, err := scannerProvider.New(cfg.Scanner)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao configurar o antivírus: %w", err)
+	}
+	scannerProvider.SetDefault(scanner)
+
+	// Cria o pool de conexões compartilhado com o banco de dados
+	pool, err := database.NewPool(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao configurar o banco de dados: %w", err)
+	}
+	database.SetPool(pool)
+
+	// Estatísticas do pool e das filas, lidas a cada coleta de /metrics
+	database.RegisterMetrics()
+	queue.RegisterMetrics()
+
+	return cfg, func() {
+		pool.Close()
+		if err := queue.Close(); err != nil {
+			slog.Warn("Erro ao fechar a conexão com as filas", "error", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Warn("Erro ao enviar os últimos traces", "error", err)
+		}
+
+		logFile.Close()
+	}, nil
+}
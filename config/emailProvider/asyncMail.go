@@ -0,0 +1,60 @@
+package emailprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/queue"
+)
+
+// SendMailJob é o job de envio de email processado pelo worker: tentativas com backoff
+// exponencial (ver RetryDelay) e tarefas concluídas mantidas por 24 horas
+var SendMailJob = queue.Job[EmailMessage]{
+	Name:       "email:send",
+	Queue:      "default",
+	MaxRetry:   8,
+	Timeout:    2 * time.Minute,
+	Retention:  24 * time.Hour,
+	RetryDelay: RetryDelay,
+}
+
+// NewSendMailTask cria a tarefa de envio do email informado
+func NewSendMailTask(emailMsg EmailMessage) (*asynq.Task, error) {
+	if err := checkRecipients(emailMsg); err != nil {
+		return nil, err
+	}
+
+	return SendMailJob.NewTask(emailMsg)
+}
+
+// SendMailAsync enfileira o envio do email para o worker, sem bloquear quem chama.
+// Retorna erro apenas se a mensagem for inválida ou a fila estiver indisponível.
+func SendMailAsync(ctx context.Context, emailMsg EmailMessage) (*asynq.TaskInfo, error) {
+	if err := checkRecipients(emailMsg); err != nil {
+		return nil, err
+	}
+
+	return SendMailJob.Enqueue(ctx, emailMsg)
+}
+
+// checkRecipients recusa mensagens sem destinatário, que nunca poderiam ser entregues
+func checkRecipients(emailMsg EmailMessage) error {
+	if len(emailMsg.To) == 0 {
+		return fmt.Errorf("%w: pelo menos um destinatário é necessário", ErrInvalidMessage)
+	}
+
+	return nil
+}
+
+// RetryDelay calcula o intervalo até a próxima tentativa: 30s, 1m, 2m, 4m... limitado a 1h
+func RetryDelay(retried int) time.Duration {
+	delay := 30 * time.Second << uint(retried)
+	if retried > 7 || delay > time.Hour {
+		return time.Hour
+	}
+
+	return delay
+}
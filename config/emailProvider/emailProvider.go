@@ -1,63 +1,78 @@
 package emailprovider
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"html/template"
-	"log"
-	"os"
-	"strconv"
 
-	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/gomail.v2"
-)
 
-var mailer *gomail.Dialer
-var fromAddress string
-var fromName string
+	"sixTask/config/appConfig"
+	"sixTask/config/metrics"
+	"sixTask/config/tracing"
+)
 
-func init() {
-	// Carregar variáveis do arquivo .env
-	if err := godotenv.Load(); err != nil {
-		log.Fatalf("Erro ao carregar o arquivo .env: %v", err)
+// ErrInvalidMessage indica uma mensagem que nunca poderá ser enviada (template ou destinatários inválidos)
+var ErrInvalidMessage = errors.New("mensagem de email inválida")
+
+var emailsSent = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Name: "emails_sent_total",
+	Help: "Emails processados por template e resultado (sent, failed ou invalid).",
+}, []string{"template", "result"})
+
+// recordSend contabiliza o resultado do envio em /metrics
+func recordSend(template string, err error) {
+	result := "sent"
+	switch {
+	case errors.Is(err, ErrInvalidMessage):
+		result = "invalid"
+	case err != nil:
+		result = "failed"
 	}
 
-	// Ler configurações SMTP do arquivo .env
-	host := os.Getenv("MAIL_HOST")
-	port, err := strconv.Atoi(os.Getenv("MAIL_PORT"))
-	if err != nil {
-		log.Fatalf("Erro ao converter MAIL_PORT: %v", err)
-	}
-	username := os.Getenv("MAIL_USERNAME")
-	password := os.Getenv("MAIL_PASSWORD")
-	fromAddress = os.Getenv("MAIL_FROM_ADDRESS")
-	fromName = os.Getenv("MAIL_FROM_NAME")
+	emailsSent.WithLabelValues(template, result).Inc()
+}
 
-	// Configurar o Dialer do Gomail
-	mailer = gomail.NewDialer(host, port, username, password)
+// sender monta o remetente a partir de MAIL_FROM_NAME e MAIL_FROM_ADDRESS
+func sender() string {
+	cfg := appConfig.Get().Mail
+	return fmt.Sprintf("%s <%s>", cfg.FromName, cfg.FromAddress)
 }
 
-// SendMail envia um e-mail usando um template.sql HTML
-func SendMail(emailMsg EmailMessage) error {
-	// Ler e parsear o template.sql
-	tmpl, err := template.ParseFiles("template.sql/" + emailMsg.Template + ".html")
+// SendMail envia um e-mail usando um template do registro (HTML com alternativa text/plain) de forma síncrona.
+// Em handlers HTTP prefira SendMailAsync, que não bloqueia a requisição. O envio gera o span
+// email.send, com a entrega pelo transporte (SMTP, arquivo ou memória) no span filho email.deliver.
+func SendMail(ctx context.Context, emailMsg EmailMessage) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "email.send", trace.WithAttributes(
+		attribute.String("email.template", emailMsg.Template),
+		attribute.Int("email.recipients", len(emailMsg.To)+len(emailMsg.Cc)+len(emailMsg.Bcc)),
+	))
+	defer func() {
+		recordSend(emailMsg.Template, err)
+		tracing.End(span, err)
+	}()
+
+	// Renderizar o template no idioma da mensagem
+	rendered, err := Render(emailMsg.Template, emailMsg.Locale, emailMsg.TemplateData)
 	if err != nil {
-		return fmt.Errorf("erro ao carregar template.sql: %v", err)
+		return fmt.Errorf("%w: erro ao renderizar template: %v", ErrInvalidMessage, err)
 	}
 
-	// Executar o template.sql com os dados fornecidos
-	var body bytes.Buffer
-	if err = tmpl.Execute(&body, emailMsg.TemplateData); err != nil {
-		return fmt.Errorf("erro ao executar template.sql: %v", err)
+	subject := emailMsg.Subject
+	if subject == "" {
+		subject = rendered.Subject
 	}
 
 	// Criar a mensagem
 	msg := gomail.NewMessage()
-	msg.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, fromAddress))
+	msg.SetHeader("From", sender())
 
 	// Configurar destinatários
 	if len(emailMsg.To) == 0 {
-		return fmt.Errorf("pelo menos um destinatário é necessário")
+		return fmt.Errorf("%w: pelo menos um destinatário é necessário", ErrInvalidMessage)
 	}
 	msg.SetHeader("To", emailMsg.To...)
 
@@ -71,16 +86,27 @@ func SendMail(emailMsg EmailMessage) error {
 		msg.SetHeader("Bcc", emailMsg.Bcc...)
 	}
 
-	msg.SetHeader("Subject", emailMsg.Subject)
-	msg.SetBody("text/html", body.String())
+	msg.SetHeader("Subject", subject)
+	msg.SetBody("text/plain", rendered.Text)
+	msg.AddAlternative("text/html", rendered.HTML)
 
 	// Adicionar anexos se houver
 	for _, attachment := range emailMsg.Attachments {
 		msg.Attach(attachment.Path, gomail.Rename(attachment.Filename))
 	}
 
-	// Enviar o email
-	if err := mailer.DialAndSend(msg); err != nil {
+	// Enviar o email pelo transporte configurado
+	transport, err := getMailer()
+	if err != nil {
+		return fmt.Errorf("erro ao configurar envio de email: %v", err)
+	}
+	_, deliver := tracing.Tracer().Start(ctx, "email.deliver",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("email.mailer", appConfig.Get().Mail.Mailer)),
+	)
+	err = transport.Send(msg)
+	tracing.End(deliver, err)
+	if err != nil {
 		return fmt.Errorf("erro ao enviar email: %v", err)
 	}
 
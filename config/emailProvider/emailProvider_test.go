@@ -0,0 +1,196 @@
+package emailprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/gomail.v2"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/storageProvider"
+)
+
+// useMemoryMailer configura o pacote para os testes e troca o transporte por um MemoryMailer
+func useMemoryMailer(t *testing.T) *MemoryMailer {
+	t.Helper()
+
+	appConfig.Set(&appConfig.Config{Mail: appConfig.MailConfig{
+		Mailer:      DriverMemory,
+		FromName:    "sixTask",
+		FromAddress: "no-reply@sixtask.test",
+		Locale:      LocalePtBR,
+	}})
+
+	mailer := NewMemoryMailer()
+	SetMailer(mailer)
+
+	return mailer
+}
+
+func TestSendMail(t *testing.T) {
+	data := map[string]any{"Nome": "Ana", "Link": "https://app.test/reset?token=abc", "ExpiraEmMinutos": 60}
+
+	tests := []struct {
+		name        string
+		msg         EmailMessage
+		wantSubject string
+		wantErr     error
+	}{
+		{"template no idioma padrão", EmailMessage{
+			To: []string{"ana@example.com"}, Template: "password_reset", TemplateData: data,
+		}, "Redefinição de senha", nil},
+		{"template em inglês", EmailMessage{
+			To: []string{"ana@example.com"}, Template: "password_reset", Locale: "en-US", TemplateData: data,
+		}, "Password reset", nil},
+		{"idioma sem tradução usa o padrão", EmailMessage{
+			To: []string{"ana@example.com"}, Template: "password_reset", Locale: "fr", TemplateData: data,
+		}, "Redefinição de senha", nil},
+		{"assunto informado substitui o do template", EmailMessage{
+			To: []string{"ana@example.com"}, Subject: "Nova senha", Template: "password_reset", TemplateData: data,
+		}, "Nova senha", nil},
+		{"sem destinatários", EmailMessage{
+			Template: "password_reset", TemplateData: data,
+		}, "", ErrInvalidMessage},
+		{"template inexistente", EmailMessage{
+			To: []string{"ana@example.com"}, Template: "inexistente",
+		}, "", ErrInvalidMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailer := useMemoryMailer(t)
+
+			err := SendMail(context.Background(), tt.msg)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SendMail() erro = %v, esperado %v", err, tt.wantErr)
+				}
+				if n := len(mailer.Messages()); n != 0 {
+					t.Errorf("nenhuma mensagem deveria ser entregue, entregues = %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendMail() erro inesperado: %v", err)
+			}
+
+			messages := mailer.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("mensagens entregues = %d, esperado 1", len(messages))
+			}
+			msg := messages[0]
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(strings.Join(msg.GetHeader("Subject"), ""))
+			if err != nil || subject != tt.wantSubject {
+				t.Errorf("Subject = %q, esperado %q", subject, tt.wantSubject)
+			}
+			if got := msg.GetHeader("To"); len(got) != 1 || got[0] != "ana@example.com" {
+				t.Errorf("To = %v", got)
+			}
+			if got := msg.GetHeader("From"); len(got) != 1 || got[0] != "sixTask <no-reply@sixtask.test>" {
+				t.Errorf("From = %v", got)
+			}
+
+			var body bytes.Buffer
+			if _, err := msg.WriteTo(&body); err != nil {
+				t.Fatalf("WriteTo() erro inesperado: %v", err)
+			}
+			if !strings.Contains(body.String(), "text/plain") || !strings.Contains(body.String(), "text/html") {
+				t.Error("a mensagem deveria ter as versões texto e HTML")
+			}
+		})
+	}
+}
+
+func TestNewMailer(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     appConfig.MailConfig
+		want    Mailer
+		wantErr bool
+	}{
+		{"memory", appConfig.MailConfig{Mailer: DriverMemory}, &MemoryMailer{}, false},
+		{"log", appConfig.MailConfig{Mailer: DriverLog, LogPath: "mail"}, &FileMailer{}, false},
+		{"file é sinônimo de log", appConfig.MailConfig{Mailer: "file"}, &FileMailer{}, false},
+		{"smtp", appConfig.MailConfig{Mailer: DriverSMTP, Host: "localhost", Port: 1025}, &SMTPMailer{}, false},
+		{"smtp é o padrão", appConfig.MailConfig{Host: "localhost", Port: 1025}, &SMTPMailer{}, false},
+		{"smtp sem porta", appConfig.MailConfig{Mailer: DriverSMTP, Host: "localhost"}, nil, true},
+		{"driver desconhecido", appConfig.MailConfig{Mailer: "sendgrid"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMailer(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewMailer() = %T, esperado erro", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMailer() erro inesperado: %v", err)
+			}
+			if gotType, wantType := fmt.Sprintf("%T", got), fmt.Sprintf("%T", tt.want); gotType != wantType {
+				t.Errorf("NewMailer() = %s, esperado %s", gotType, wantType)
+			}
+		})
+	}
+}
+
+func TestFileMailerStoresMessages(t *testing.T) {
+	root := t.TempDir()
+	storageProvider.SetDefault(storageProvider.NewLocalStorage(root, "/storage", time.Minute))
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", "no-reply@sixtask.test")
+	msg.SetHeader("To", "ana@example.com")
+	msg.SetHeader("Subject", "Teste")
+	msg.SetBody("text/plain", "Olá")
+
+	if err := NewFileMailer("mail").Send(msg); err != nil {
+		t.Fatalf("Send() erro inesperado: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(root, "mail", "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("arquivos .eml gravados = %v, %v, esperado 1", files, err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("ReadFile() erro inesperado: %v", err)
+	}
+	if !strings.Contains(string(content), "To: ana@example.com") {
+		t.Errorf("a mensagem gravada deveria conter o destinatário:\n%s", content)
+	}
+}
+
+func TestGetMailerIsLazy(t *testing.T) {
+	mailerMu.Lock()
+	mailer, mailerErr, mailerInit = nil, nil, false
+	mailerMu.Unlock()
+
+	appConfig.Set(&appConfig.Config{Mail: appConfig.MailConfig{Mailer: "sendgrid"}})
+
+	// A configuração inválida só é percebida no primeiro uso e não é reavaliada a cada envio
+	if _, err := getMailer(); err == nil {
+		t.Fatal("getMailer() deveria falhar com MAIL_MAILER inválido")
+	}
+
+	appConfig.Set(&appConfig.Config{Mail: appConfig.MailConfig{Mailer: DriverMemory}})
+	if _, err := getMailer(); err == nil {
+		t.Error("getMailer() deveria manter o resultado do primeiro uso")
+	}
+
+	SetMailer(NewMemoryMailer())
+	if m, err := getMailer(); err != nil || m == nil {
+		t.Errorf("getMailer() depois de SetMailer() = %v, %v", m, err)
+	}
+}
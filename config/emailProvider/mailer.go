@@ -0,0 +1,152 @@
+package emailprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/storageProvider"
+)
+
+// Mailer representa o transporte que entrega uma mensagem já montada
+type Mailer interface {
+	Send(msg *gomail.Message) error
+}
+
+// Drivers disponíveis em MAIL_MAILER
+const (
+	DriverSMTP   = "smtp"
+	DriverLog    = "log"
+	DriverMemory = "memory"
+)
+
+var (
+	mailerMu   sync.Mutex
+	mailer     Mailer
+	mailerErr  error
+	mailerInit bool
+)
+
+// getMailer retorna o transporte configurado, criando-o no primeiro uso
+func getMailer() (Mailer, error) {
+	mailerMu.Lock()
+	defer mailerMu.Unlock()
+
+	if !mailerInit {
+		mailer, mailerErr = NewMailer(appConfig.Get().Mail)
+		mailerInit = true
+	}
+
+	return mailer, mailerErr
+}
+
+// SetMailer substitui o transporte em uso, por exemplo por um MemoryMailer em testes
+func SetMailer(m Mailer) {
+	mailerMu.Lock()
+	defer mailerMu.Unlock()
+
+	mailer, mailerErr, mailerInit = m, nil, true
+}
+
+// NewMailer cria o transporte indicado em cfg.Mailer (smtp, log ou memory)
+func NewMailer(cfg appConfig.MailConfig) (Mailer, error) {
+	switch cfg.Mailer {
+	case "", DriverSMTP:
+		return NewSMTPMailer(cfg)
+	case DriverLog, "file":
+		return NewFileMailer(cfg.LogPath), nil
+	case DriverMemory:
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("MAIL_MAILER inválido: %q", cfg.Mailer)
+	}
+}
+
+// SMTPMailer entrega as mensagens por SMTP
+type SMTPMailer struct {
+	dialer *gomail.Dialer
+}
+
+// NewSMTPMailer cria o transporte SMTP com o servidor e as credenciais da configuração
+func NewSMTPMailer(cfg appConfig.MailConfig) (*SMTPMailer, error) {
+	if cfg.Port <= 0 {
+		return nil, fmt.Errorf("MAIL_PORT inválida: %d", cfg.Port)
+	}
+
+	return &SMTPMailer{
+		dialer: gomail.NewDialer(cfg.Host, cfg.Port, cfg.Username, cfg.Password),
+	}, nil
+}
+
+// Send envia a mensagem abrindo uma conexão com o servidor SMTP
+func (m *SMTPMailer) Send(msg *gomail.Message) error {
+	return m.dialer.DialAndSend(msg)
+}
+
+// FileMailer grava cada mensagem como um arquivo .eml no storage, útil em desenvolvimento
+type FileMailer struct {
+	prefix string
+}
+
+// NewFileMailer cria o transporte que grava as mensagens sob o prefixo informado do storage
+func NewFileMailer(prefix string) *FileMailer {
+	return &FileMailer{prefix: prefix}
+}
+
+// Send grava a mensagem em <prefixo>/<timestamp>.eml pelo storage configurado (ver storageProvider.Default)
+func (m *FileMailer) Send(msg *gomail.Message) error {
+	store, err := storageProvider.Default()
+	if err != nil {
+		return err
+	}
+
+	var content bytes.Buffer
+	if _, err := msg.WriteTo(&content); err != nil {
+		return err
+	}
+
+	key := path.Join(m.prefix, time.Now().Format("20060102-150405.000000000")+".eml")
+	return store.Put(context.Background(), key, &content, int64(content.Len()), "message/rfc822")
+}
+
+// MemoryMailer guarda as mensagens em memória para inspeção em testes
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []*gomail.Message
+}
+
+// NewMemoryMailer cria um transporte em memória vazio
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send registra a mensagem
+func (m *MemoryMailer) Send(msg *gomail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages retorna as mensagens registradas até o momento
+func (m *MemoryMailer) Messages() []*gomail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*gomail.Message(nil), m.messages...)
+}
+
+// Reset descarta as mensagens registradas
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
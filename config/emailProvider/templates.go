@@ -0,0 +1,300 @@
+package emailprovider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"sixTask/config/appConfig"
+	emailtemplates "sixTask/template"
+)
+
+// Idiomas suportados pelos templates de email
+const (
+	LocalePtBR = "pt_BR"
+	LocaleEn   = "en"
+)
+
+// ErrTemplateNotFound indica um template inexistente no registro
+var ErrTemplateNotFound = errors.New("template de email não encontrado")
+
+// RenderedEmail é o resultado da renderização de um template
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// templateRegistry guarda os templates já parseados, indexados por "<locale>/<nome>"
+type templateRegistry struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+var (
+	registryOnce sync.Once
+	registry     *templateRegistry
+	registryErr  error
+)
+
+// getRegistry carrega os templates embutidos no primeiro uso
+func getRegistry() (*templateRegistry, error) {
+	registryOnce.Do(func() {
+		registry, registryErr = loadTemplates(emailtemplates.FS)
+	})
+
+	return registry, registryErr
+}
+
+// loadTemplates parseia cada email junto com o layout base e os partials
+func loadTemplates(fsys fs.FS) (*templateRegistry, error) {
+	files, err := fs.Glob(fsys, "emails/*/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &templateRegistry{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		name := strings.TrimSuffix(path.Base(file), ".html")
+		key := locale + "/" + name
+
+		tmpl, err := htmltemplate.New("base.html").
+			Funcs(htmltemplate.FuncMap(templateFuncs(locale))).
+			ParseFS(fsys, "layouts/base.html", "partials/*.html", file)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar template %s: %v", key, err)
+		}
+		reg.html[key] = tmpl
+
+		// Versão texto opcional escrita à mão
+		textFile := strings.TrimSuffix(file, ".html") + ".txt"
+		if _, err := fs.Stat(fsys, textFile); err == nil {
+			textTmpl, err := texttemplate.New(path.Base(textFile)).
+				Funcs(texttemplate.FuncMap(templateFuncs(locale))).
+				ParseFS(fsys, textFile)
+			if err != nil {
+				return nil, fmt.Errorf("erro ao carregar template %s.txt: %v", key, err)
+			}
+			reg.text[key] = textTmpl
+		}
+	}
+
+	return reg, nil
+}
+
+// Render renderiza o template no idioma informado, usando o idioma padrão quando não houver tradução
+func Render(name, locale string, data interface{}) (RenderedEmail, error) {
+	reg, err := getRegistry()
+	if err != nil {
+		return RenderedEmail{}, err
+	}
+
+	locale = NormalizeLocale(locale)
+	key := locale + "/" + name
+	if _, ok := reg.html[key]; !ok {
+		key = DefaultLocale() + "/" + name
+	}
+
+	tmpl, ok := reg.html[key]
+	if !ok {
+		return RenderedEmail{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return RenderedEmail{}, err
+	}
+
+	var subject bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return RenderedEmail{}, err
+	}
+
+	rendered := RenderedEmail{
+		Subject: strings.TrimSpace(html.UnescapeString(subject.String())),
+		HTML:    body.String(),
+	}
+
+	if textTmpl, ok := reg.text[key]; ok {
+		var text bytes.Buffer
+		if err := textTmpl.Execute(&text, data); err != nil {
+			return RenderedEmail{}, err
+		}
+		rendered.Text = text.String()
+	} else {
+		rendered.Text = htmlToText(rendered.HTML)
+	}
+
+	return rendered, nil
+}
+
+// TemplateNames lista os templates disponíveis por idioma
+func TemplateNames() (map[string][]string, error) {
+	reg, err := getRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string][]string)
+	for key := range reg.html {
+		locale, name, _ := strings.Cut(key, "/")
+		names[name] = append(names[name], locale)
+	}
+	for name := range names {
+		sort.Strings(names[name])
+	}
+
+	return names, nil
+}
+
+// DefaultLocale retorna o idioma padrão dos emails (MAIL_LOCALE, padrão pt_BR)
+func DefaultLocale() string {
+	if locale := normalizeLocaleTag(appConfig.Get().Mail.Locale); locale != "" {
+		return locale
+	}
+
+	return LocalePtBR
+}
+
+// NormalizeLocale converte variações como "en-US" ou "pt-br" para um idioma suportado
+func NormalizeLocale(locale string) string {
+	if normalized := normalizeLocaleTag(locale); normalized != "" {
+		return normalized
+	}
+
+	return DefaultLocale()
+}
+
+// LocaleFromAcceptLanguage escolhe o idioma do email a partir do cabeçalho Accept-Language
+func LocaleFromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		if locale := normalizeLocaleTag(tag); locale != "" {
+			return locale
+		}
+	}
+
+	return DefaultLocale()
+}
+
+// normalizeLocaleTag retorna o idioma suportado correspondente à tag ou vazio
+func normalizeLocaleTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "-", "_"), "_")
+
+	switch language {
+	case "pt":
+		return LocalePtBR
+	case "en":
+		return LocaleEn
+	default:
+		return ""
+	}
+}
+
+// templateFuncs retorna as funções disponíveis nos templates do idioma
+func templateFuncs(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"locale": func() string { return locale },
+		"lang":   func() string { return strings.ReplaceAll(locale, "_", "-") },
+		"dict":   dict,
+		"duration": func(minutes interface{}) string {
+			return formatMinutes(locale, minutes)
+		},
+	}
+}
+
+// dict monta um mapa a partir de pares chave/valor, usado para passar parâmetros aos partials
+func dict(values ...interface{}) (map[string]interface{}, error) {
+	if len(values)%2 != 0 {
+		return nil, errors.New("dict espera pares de chave e valor")
+	}
+
+	result := make(map[string]interface{}, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		key, ok := values[i].(string)
+		if !ok {
+			return nil, errors.New("as chaves de dict devem ser strings")
+		}
+		result[key] = values[i+1]
+	}
+
+	return result, nil
+}
+
+// durationUnits traz, por idioma, minuto/minutos/hora/horas
+var durationUnits = map[string][4]string{
+	LocalePtBR: {"minuto", "minutos", "hora", "horas"},
+	LocaleEn:   {"minute", "minutes", "hour", "hours"},
+}
+
+// formatMinutes descreve uma duração em minutos no idioma do email.
+// Aceita qualquer número, pois os dados chegam do payload JSON da fila como float64.
+func formatMinutes(locale string, value interface{}) string {
+	var minutes int
+	switch v := value.(type) {
+	case int:
+		minutes = v
+	case int64:
+		minutes = int(v)
+	case float64:
+		minutes = int(v)
+	default:
+		return ""
+	}
+
+	units := durationUnits[LocalePtBR]
+	if translated, ok := durationUnits[locale]; ok {
+		units = translated
+	}
+
+	unit, plural, amount := units[0], units[1], minutes
+	if minutes >= 60 && minutes%60 == 0 {
+		unit, plural, amount = units[2], units[3], minutes/60
+	}
+
+	if amount == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+
+	return fmt.Sprintf("%d %s", amount, plural)
+}
+
+var (
+	headRegexp      = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	linkRegexp      = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	lineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr)>`)
+	tagRegexp       = regexp.MustCompile(`(?s)<[^>]+>`)
+	blankRegexp     = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText gera a versão text/plain do email a partir do HTML renderizado
+func htmlToText(body string) string {
+	text := headRegexp.ReplaceAllString(body, "")
+	text = linkRegexp.ReplaceAllString(text, "$2 ($1)")
+	text = lineBreakRegexp.ReplaceAllString(text, "\n")
+	text = tagRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankRegexp.ReplaceAllString(text, "\n\n"))
+}
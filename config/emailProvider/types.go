@@ -6,13 +6,15 @@ type EmailAttachment struct {
 	Path     string
 }
 
-// EmailMessage representa a configuração completa de um email
+// EmailMessage representa a configuração completa de um email.
+// Subject vazio usa o assunto definido no template; Locale vazio usa MAIL_LOCALE.
 type EmailMessage struct {
 	To           []string
 	Cc           []string `json:"cc,omitempty"`
 	Bcc          []string `json:"bcc,omitempty"`
 	Subject      string
 	Template     string
+	Locale       string            `json:"locale,omitempty"`
 	TemplateData interface{}       `json:"templateData,omitempty"`
 	Attachments  []EmailAttachment `json:"attachments,omitempty"`
 }
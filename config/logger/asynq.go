@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// AsynqLogger encaminha os registros internos do asynq (worker e scheduler) para o slog
+type AsynqLogger struct{}
+
+func (AsynqLogger) Debug(args ...interface{}) { asynqLog(slog.LevelDebug, args) }
+func (AsynqLogger) Info(args ...interface{})  { asynqLog(slog.LevelInfo, args) }
+func (AsynqLogger) Warn(args ...interface{})  { asynqLog(slog.LevelWarn, args) }
+func (AsynqLogger) Error(args ...interface{}) { asynqLog(slog.LevelError, args) }
+
+// Fatal registra o erro e encerra o processo, como o logger padrão do asynq
+func (AsynqLogger) Fatal(args ...interface{}) {
+	asynqLog(slog.LevelError, args)
+	os.Exit(1)
+}
+
+func asynqLog(level slog.Level, args []interface{}) {
+	slog.Default().Log(context.Background(), level, fmt.Sprint(args...), "component", "asynq")
+}
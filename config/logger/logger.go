@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"sixTask/config/appConfig"
+)
+
+// Setup configura o slog padrão do processo com o nível e o formato da configuração.
+// Os registros vão para a saída padrão (LOG_STDOUT) e para <LOG_DIR>/<name>-<data>.log,
+// rotacionado por dia e por tamanho. Chamadas ao pacote log também passam pelo slog,
+// no nível info. O io.Closer retornado fecha o arquivo de log no encerramento.
+func Setup(cfg appConfig.LogConfig, name string) (io.Closer, error) {
+	file, err := newRotatingFile(cfg.Dir, name, cfg.MaxSize, cfg.MaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var output io.Writer = file
+	if cfg.Stdout {
+		output = io.MultiWriter(os.Stdout, file)
+	}
+
+	slog.SetDefault(slog.New(NewHandler(output, cfg)))
+
+	return file, nil
+}
+
+// NewHandler cria o handler do slog no formato configurado, acrescentando aos registros
+// o request_id e os atributos guardados no contexto (ver WithRequestID e With)
+func NewHandler(w io.Writer, cfg appConfig.LogConfig) slog.Handler {
+	opts := &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       ParseLevel(cfg.Level),
+		ReplaceAttr: shortSource,
+	}
+
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return contextHandler{handler}
+}
+
+// ParseLevel converte debug, info, warn ou error no nível do slog (padrão info)
+func ParseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// shortSource reduz a origem do registro a pasta/arquivo.go:linha, como o log.Lshortfile
+func shortSource(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.SourceKey || len(groups) > 0 {
+		return a
+	}
+
+	source, ok := a.Value.Any().(*slog.Source)
+	if !ok || source == nil {
+		return a
+	}
+	// Registros sem origem conhecida (ex.: vindos do pacote log) ficam sem o campo
+	if source.File == "" {
+		return slog.Attr{}
+	}
+
+	file := filepath.Join(filepath.Base(filepath.Dir(source.File)), filepath.Base(source.File))
+	return slog.String(slog.SourceKey, file+":"+strconv.Itoa(source.Line))
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateLayout é a data no nome dos arquivos de log (ex.: app-2025-05-20.log)
+const dateLayout = "2006-01-02"
+
+// rotatingFile grava os logs em <dir>/<name>-<data>.log. A cada escrita confere se o dia
+// mudou ou se o arquivo atingiu maxSize; nesse caso abre o próximo arquivo
+// (<name>-<data>.1.log, .2.log, ...) e remove em segundo plano os arquivos com mais de maxDays.
+type rotatingFile struct {
+	mu      sync.Mutex
+	dir     string
+	name    string
+	maxSize int64
+	maxDays int
+
+	file *os.File
+	day  string
+	seq  int
+	size int64
+}
+
+// newRotatingFile cria o diretório e abre o arquivo do dia, continuando o último já existente
+func newRotatingFile(dir, name string, maxSize int64, maxDays int) (*rotatingFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de logs: %w", err)
+	}
+
+	r := &rotatingFile{dir: dir, name: name, maxSize: maxSize, maxDays: maxDays}
+
+	now := time.Now()
+	r.day = now.Format(dateLayout)
+	r.seq = r.lastSeq(r.day)
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	go r.cleanup(now)
+
+	return r, nil
+}
+
+// Write grava os bytes no arquivo atual, rotacionando antes quando necessário
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	now := time.Now()
+	day := now.Format(dateLayout)
+	if day != r.day || (r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize) {
+		if err := r.rotate(day); err != nil {
+			return 0, err
+		}
+		go r.cleanup(now)
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// Close fecha o arquivo atual; escritas posteriores falham
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
+
+// rotate fecha o arquivo atual e abre o próximo: o primeiro do novo dia ou a próxima parte do dia
+func (r *rotatingFile) rotate(day string) error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	if day != r.day {
+		r.day, r.seq = day, 0
+	} else {
+		r.seq++
+	}
+
+	return r.open()
+}
+
+// open abre (ou cria) o arquivo do dia e da parte atuais
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path(r.day, r.seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo de log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file, r.size = file, info.Size()
+
+	return nil
+}
+
+func (r *rotatingFile) path(day string, seq int) string {
+	if seq == 0 {
+		return filepath.Join(r.dir, fmt.Sprintf("%s-%s.log", r.name, day))
+	}
+
+	return filepath.Join(r.dir, fmt.Sprintf("%s-%s.%d.log", r.name, day, seq))
+}
+
+// lastSeq retorna a maior parte já gravada no dia, para continuar de onde o processo parou
+func (r *rotatingFile) lastSeq(day string) int {
+	files, _ := filepath.Glob(filepath.Join(r.dir, fmt.Sprintf("%s-%s.*.log", r.name, day)))
+
+	last := 0
+	for _, file := range files {
+		part := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), r.name+"-"+day+"."), ".log")
+		if seq, err := strconv.Atoi(part); err == nil && seq > last {
+			last = seq
+		}
+	}
+
+	return last
+}
+
+// cleanup remove os arquivos de log com data anterior aos últimos maxDays dias
+func (r *rotatingFile) cleanup(now time.Time) {
+	files, err := filepath.Glob(filepath.Join(r.dir, r.name+"-*.log"))
+	if err != nil {
+		slog.Error("Erro ao listar arquivos de log", "error", err)
+		return
+	}
+
+	cutoff := now.AddDate(0, 0, -r.maxDays).Format(dateLayout)
+	for _, file := range files {
+		day := strings.TrimPrefix(filepath.Base(file), r.name+"-")
+		if len(day) < len(dateLayout) {
+			continue
+		}
+		day = day[:len(dateLayout)]
+		if _, err := time.Parse(dateLayout, day); err != nil || day > cutoff {
+			continue
+		}
+
+		if err := os.Remove(file); err != nil {
+			slog.Error("Erro ao remover arquivo de log antigo", "file", file, "error", err)
+		} else {
+			slog.Info("Arquivo de log antigo removido", "file", file)
+		}
+	}
+}
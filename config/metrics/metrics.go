@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry é o registro exposto em /metrics pelo servidor e pelo worker. Já inclui as métricas
+// do runtime do Go (go_*) e do processo (process_*).
+var Registry = prometheus.NewRegistry()
+
+// Factory cria métricas já registradas em Registry, como em
+// metrics.Factory.NewCounterVec(prometheus.CounterOpts{...}, []string{"result"})
+var Factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Register acrescenta coletores ao registro, como os que leem as estatísticas do pool do
+// banco e o tamanho das filas a cada coleta. Entra em pânico se uma métrica for registrada duas vezes.
+func Register(cs ...prometheus.Collector) {
+	Registry.MustRegister(cs...)
+}
+
+// Handler retorna o handler HTTP do registro, no formato de exposição do Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
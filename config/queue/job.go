@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"sixTask/config/tracing"
+)
+
+// Job define um tipo de job: o nome da tarefa, o payload tipado e as opções de enfileiramento.
+// Produtores e worker usam a mesma definição, de modo que o formato do payload não diverge
+// entre quem enfileira e quem processa.
+type Job[P any] struct {
+	Name  string
+	Queue string
+	// MaxRetry é a quantidade de novas tentativas; zero usa o padrão do asynq (25)
+	MaxRetry int
+	Timeout  time.Duration
+	// Retention mantém a tarefa concluída disponível para consulta (ver ResultRetention)
+	Retention time.Duration
+	// RetryDelay substitui o intervalo padrão do asynq entre as tentativas
+	RetryDelay func(retried int) time.Duration
+}
+
+// Options retorna as opções de enfileiramento definidas no job
+func (j Job[P]) Options() []asynq.Option {
+	var opts []asynq.Option
+	if j.Queue != "" {
+		opts = append(opts, asynq.Queue(j.Queue))
+	}
+	if j.MaxRetry > 0 {
+		opts = append(opts, asynq.MaxRetry(j.MaxRetry))
+	}
+	if j.Timeout > 0 {
+		opts = append(opts, asynq.Timeout(j.Timeout))
+	}
+	if j.Retention > 0 {
+		opts = append(opts, asynq.Retention(j.Retention))
+	}
+
+	return opts
+}
+
+// NewTask cria a tarefa com o payload informado. As opções extras têm precedência sobre as do job.
+func (j Job[P]) NewTask(payload P, opts ...asynq.Option) (*asynq.Task, error) {
+	return j.NewTaskContext(context.Background(), payload, opts...)
+}
+
+// NewTaskContext cria a tarefa levando no payload o request_id do contexto, de modo que os
+// registros do worker possam ser correlacionados com a requisição que enfileirou o job
+func (j Job[P]) NewTaskContext(ctx context.Context, payload P, opts ...asynq.Option) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao codificar payload de %s: %w", j.Name, err)
+	}
+
+	return asynq.NewTask(j.Name, withMetadata(ctx, data), append(j.Options(), opts...)...), nil
+}
+
+// Enqueue cria a tarefa e a enfileira, devolvendo as informações do asynq (inclusive o ID do job).
+// O enfileiramento gera um span, que o worker continua ao processar a tarefa.
+func (j Job[P]) Enqueue(ctx context.Context, payload P, opts ...asynq.Option) (info *asynq.TaskInfo, err error) {
+	ctx, span := startPublishSpan(ctx, j.Name)
+	defer func() {
+		if info != nil {
+			span.SetAttributes(semconv.MessagingDestinationName(info.Queue), semconv.MessagingMessageID(info.ID))
+		}
+		tracing.End(span, err)
+	}()
+
+	task, err := j.NewTaskContext(ctx, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Client().EnqueueContext(ctx, task)
+}
+
+// Decode lê o payload da tarefa. Payloads inválidos não são repetidos (asynq.SkipRetry).
+func (j Job[P]) Decode(task *asynq.Task) (P, error) {
+	var payload P
+	if len(task.Payload()) == 0 {
+		return payload, nil
+	}
+
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return payload, fmt.Errorf("payload de %s inválido: %v: %w", j.Name, err, asynq.SkipRetry)
+	}
+
+	return payload, nil
+}
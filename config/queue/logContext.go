@@ -0,0 +1,21 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/logger"
+)
+
+// LogContext devolve o contexto do job com o request_id de quem o enfileirou e os atributos
+// job e job_id, incluídos nos registros feitos com slog.InfoContext, slog.ErrorContext, etc.
+func LogContext(ctx context.Context, task *asynq.Task) context.Context {
+	if id := metadataOf(task.Payload()).RequestID; id != "" {
+		ctx = logger.WithRequestID(ctx, id)
+	}
+
+	taskID, _ := asynq.GetTaskID(ctx)
+
+	return logger.With(ctx, "job", task.Type(), "job_id", taskID)
+}
@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"sixTask/config/logger"
+)
+
+// Campos acrescentados ao payload para levar ao worker o contexto de quem enfileirou a tarefa.
+// O asynq não tem cabeçalhos nas tarefas, então eles viajam junto com o payload JSON.
+const (
+	requestIDField    = "request_id"
+	traceContextField = "trace_context"
+)
+
+// taskMetadata é o contexto de origem lido do payload no worker
+type taskMetadata struct {
+	RequestID    string                 `json:"request_id"`
+	TraceContext propagation.MapCarrier `json:"trace_context"`
+}
+
+// withMetadata acrescenta ao payload JSON o request_id e o trace (traceparent) do contexto.
+// Payloads que não são objetos JSON seguem sem alteração; campos já existentes não são substituídos.
+func withMetadata(ctx context.Context, data []byte) []byte {
+	extra := map[string]any{}
+	if id := logger.RequestID(ctx); id != "" {
+		extra[requestIDField] = id
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) > 0 {
+		extra[traceContextField] = carrier
+	}
+	if len(extra) == 0 {
+		return data
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return data
+	}
+	for key, value := range extra {
+		if _, exists := fields[key]; exists {
+			continue
+		}
+		fields[key], _ = json.Marshal(value)
+	}
+
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+
+	return out
+}
+
+// metadataOf lê do payload o contexto de origem da tarefa
+func metadataOf(payload []byte) taskMetadata {
+	var meta taskMetadata
+	json.Unmarshal(payload, &meta)
+
+	return meta
+}
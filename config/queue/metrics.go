@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sixTask/config/metrics"
+)
+
+var (
+	taskDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "asynq_task_duration_seconds",
+		Help:    "Duração do processamento das tarefas neste worker.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"task"})
+	tasksProcessed = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "asynq_tasks_processed_total",
+		Help: "Tarefas processadas neste worker por resultado (success, failure ou skipped).",
+	}, []string{"task", "result"})
+)
+
+// ObserveTask registra a duração e o resultado de uma tarefa processada pelo worker.
+// Erros com asynq.SkipRetry contam como skipped: a tarefa é arquivada sem novas tentativas.
+func ObserveTask(task *asynq.Task, duration time.Duration, err error) {
+	result := "success"
+	switch {
+	case errors.Is(err, asynq.SkipRetry):
+		result = "skipped"
+	case err != nil:
+		result = "failure"
+	}
+
+	taskDuration.WithLabelValues(task.Type()).Observe(duration.Seconds())
+	tasksProcessed.WithLabelValues(task.Type(), result).Inc()
+}
+
+// RegisterMetrics expõe em /metrics a situação das filas no Redis, consultada a cada coleta.
+// Os totais de processadas e falhas são do asynq e valem para todos os workers.
+func RegisterMetrics() {
+	metrics.Register(queueCollector{inspector: Inspector()})
+}
+
+var (
+	queueTasks     = prometheus.NewDesc("asynq_queue_tasks", "Tarefas na fila por estado.", []string{"queue", "state"}, nil)
+	queueLatency   = prometheus.NewDesc("asynq_queue_latency_seconds", "Tempo de espera da tarefa pendente mais antiga.", []string{"queue"}, nil)
+	queuePaused    = prometheus.NewDesc("asynq_queue_paused", "Indica se a fila está pausada (1) ou não (0).", []string{"queue"}, nil)
+	queueProcessed = prometheus.NewDesc("asynq_queue_processed_total", "Tarefas processadas na fila desde a sua criação, por todos os workers.", []string{"queue"}, nil)
+	queueFailed    = prometheus.NewDesc("asynq_queue_failed_total", "Tarefas que falharam na fila desde a sua criação, por todos os workers.", []string{"queue"}, nil)
+)
+
+// queueCollector consulta o Redis a cada coleta de /metrics
+type queueCollector struct {
+	inspector *asynq.Inspector
+}
+
+func (queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{queueTasks, queueLatency, queuePaused, queueProcessed, queueFailed} {
+		ch <- desc
+	}
+}
+
+func (c queueCollector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		slog.Warn("Erro ao consultar as filas para as métricas", "error", err)
+		return
+	}
+
+	for _, name := range queues {
+		info, err := c.inspector.GetQueueInfo(name)
+		if err != nil {
+			slog.Warn("Erro ao consultar a fila para as métricas", "queue", name, "error", err)
+			continue
+		}
+
+		for _, state := range []struct {
+			name  string
+			count int
+		}{
+			{"pending", info.Pending},
+			{"active", info.Active},
+			{"scheduled", info.Scheduled},
+			{"retry", info.Retry},
+			{"archived", info.Archived},
+			{"completed", info.Completed},
+		} {
+			ch <- prometheus.MustNewConstMetric(queueTasks, prometheus.GaugeValue, float64(state.count), name, state.name)
+		}
+
+		paused := 0.0
+		if info.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(queueLatency, prometheus.GaugeValue, info.Latency.Seconds(), name)
+		ch <- prometheus.MustNewConstMetric(queuePaused, prometheus.GaugeValue, paused, name)
+		ch <- prometheus.MustNewConstMetric(queueProcessed, prometheus.CounterValue, float64(info.ProcessedTotal), name)
+		ch <- prometheus.MustNewConstMetric(queueFailed, prometheus.CounterValue, float64(info.FailedTotal), name)
+	}
+}
@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// ResultRetention é por quanto tempo as tarefas concluídas acompanhadas pela API de jobs
+// continuam disponíveis para consulta (asynq.Retention)
+const ResultRetention = 24 * time.Hour
+
+// Progress é o andamento de um job, gravado no resultado da tarefa pelo ResultWriter do asynq.
+// Result guarda o resultado final do job, quando houver.
+type Progress struct {
+	Percent int    `json:"percent"`
+	Message string `json:"message,omitempty"`
+	Result  any    `json:"result,omitempty"`
+}
+
+type taskKey struct{}
+
+// WithTask guarda no contexto a tarefa em execução, permitindo que os serviços chamados
+// pelo job informem o andamento sem receber a tarefa como parâmetro
+func WithTask(ctx context.Context, task *asynq.Task) context.Context {
+	return context.WithValue(ctx, taskKey{}, task)
+}
+
+// ReportProgress grava o percentual concluído (0 a 100) e uma mensagem no resultado da tarefa.
+// Fora de um job (contexto sem tarefa) não faz nada.
+func ReportProgress(ctx context.Context, percent int, message string) error {
+	return write(ctx, Progress{Percent: min(max(percent, 0), 100), Message: message})
+}
+
+// SetResult grava o resultado final do job, marcando o andamento como 100%
+func SetResult(ctx context.Context, message string, result any) error {
+	return write(ctx, Progress{Percent: 100, Message: message, Result: result})
+}
+
+func write(ctx context.Context, progress Progress) error {
+	task, ok := ctx.Value(taskKey{}).(*asynq.Task)
+	if !ok || task.ResultWriter() == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	_, err = task.ResultWriter().Write(data)
+	return err
+}
+
+// Requester é incorporado aos payloads dos jobs acompanhados pela API de jobs, identificando
+// quem os disparou. Jobs sem solicitante só podem ser consultados por gerentes.
+type Requester struct {
+	RequestedBy int64 `json:"requested_by,omitempty"`
+}
@@ -1,7 +1,69 @@
 package queue
 
-import "github.com/hibiken/asynq"
+import (
+	"sync"
 
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/appConfig"
+)
+
+// RedisOpt retorna a conexão com o Redis da configuração (REDIS_ADDR, REDIS_PASSWORD e REDIS_DB),
+// compartilhada por clientes, worker, scheduler e monitor
+func RedisOpt() asynq.RedisClientOpt {
+	cfg := appConfig.Get().Redis
+
+	return asynq.RedisClientOpt{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+}
+
+// Conect cria um novo cliente do asynq, que deve ser fechado por quem o criou.
+// Para enfileirar tarefas use Client, que reaproveita a mesma conexão.
 func Conect() *asynq.Client {
-	return asynq.NewClient(asynq.RedisClientOpt{Addr: "localhost:6379"})
+	return asynq.NewClient(RedisOpt())
+}
+
+// Inspector cria o inspetor do asynq usado para consultar a situação das tarefas
+func Inspector() *asynq.Inspector {
+	return asynq.NewInspector(RedisOpt())
+}
+
+var (
+	clientMu     sync.Mutex
+	sharedClient *asynq.Client
+)
+
+// Client retorna o cliente do asynq compartilhado pelos enfileiramentos e pela verificação de saúde.
+// O cliente mantém um pool de conexões com o Redis, por isso é criado uma única vez, no primeiro uso.
+func Client() *asynq.Client {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if sharedClient == nil {
+		sharedClient = Conect()
+	}
+
+	return sharedClient
+}
+
+// Close fecha o cliente compartilhado, se ele chegou a ser criado
+func Close() error {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if sharedClient == nil {
+		return nil
+	}
+
+	err := sharedClient.Close()
+	sharedClient = nil
+	return err
+}
+
+// Ping verifica a conexão com o Redis, reaproveitando o cliente compartilhado
+func Ping() error {
+	return Client().Ping()
 }
@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// Registry reúne os jobs processados pelo worker, com o handler tipado de cada um
+type Registry struct {
+	handlers map[string]asynq.Handler
+	delays   map[string]func(retried int) time.Duration
+}
+
+// NewRegistry cria um registro de jobs vazio
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: make(map[string]asynq.Handler),
+		delays:   make(map[string]func(retried int) time.Duration),
+	}
+}
+
+// Register associa o job ao handler que recebe o payload já decodificado.
+// Registrar o mesmo nome duas vezes é um erro de programação e interrompe a inicialização.
+func Register[P any](r *Registry, job Job[P], handle func(ctx context.Context, payload P) error) {
+	if job.Name == "" {
+		panic("queue: job sem nome")
+	}
+	if _, exists := r.handlers[job.Name]; exists {
+		panic(fmt.Sprintf("queue: job %s registrado mais de uma vez", job.Name))
+	}
+
+	r.handlers[job.Name] = asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		payload, err := job.Decode(task)
+		if err != nil {
+			return err
+		}
+
+		return handle(ctx, payload)
+	})
+	if job.RetryDelay != nil {
+		r.delays[job.Name] = job.RetryDelay
+	}
+}
+
+// Names retorna os nomes dos jobs registrados, em ordem alfabética
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ServeMux cria o multiplexador do worker com os handlers registrados e os middlewares informados
+func (r *Registry) ServeMux(middlewares ...asynq.MiddlewareFunc) *asynq.ServeMux {
+	mux := asynq.NewServeMux()
+	mux.Use(middlewares...)
+	for _, name := range r.Names() {
+		mux.Handle(name, r.handlers[name])
+	}
+
+	return mux
+}
+
+// RetryDelay define o intervalo entre as tentativas de cada job, usando o RetryDelay do job
+// quando definido e o padrão do asynq nos demais
+func (r *Registry) RetryDelay(retried int, err error, task *asynq.Task) time.Duration {
+	if delay, ok := r.delays[task.Type()]; ok {
+		return delay(retried)
+	}
+
+	return asynq.DefaultRetryDelayFunc(retried, err, task)
+}
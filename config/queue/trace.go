@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"sixTask/config/tracing"
+)
+
+var messagingSystem = semconv.MessagingSystemKey.String("asynq")
+
+// startPublishSpan abre o span do enfileiramento; o trace_context gravado no payload aponta para ele
+func startPublishSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "publish "+name,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingSystem, semconv.MessagingOperationTypePublish, attribute.String("asynq.task_type", name)),
+	)
+}
+
+// StartProcessSpan abre o span do processamento da tarefa no worker, continuando o trace de
+// quem a enfileirou (campo trace_context do payload). Consultas e emails do job viram spans filhos.
+func StartProcessSpan(ctx context.Context, task *asynq.Task) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataOf(task.Payload()).TraceContext)
+
+	taskID, _ := asynq.GetTaskID(ctx)
+	queueName, _ := asynq.GetQueueName(ctx)
+	retried, _ := asynq.GetRetryCount(ctx)
+
+	return tracing.Tracer().Start(ctx, "process "+task.Type(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			messagingSystem,
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingDestinationName(queueName),
+			semconv.MessagingMessageID(taskID),
+			attribute.String("asynq.task_type", task.Type()),
+			attribute.Int("asynq.retry_count", retried),
+		),
+	)
+}
@@ -0,0 +1,109 @@
+package scannerProvider
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// clamavChunkSize é o tamanho dos blocos enviados no comando INSTREAM
+const clamavChunkSize = 64 * 1024
+
+// ClamAVScanner envia os arquivos ao clamd pelo protocolo INSTREAM
+type ClamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner cria o scanner para o endereço informado:
+// "tcp://host:3310" ou "unix:///var/run/clamav/clamd.ctl"
+func NewClamAVScanner(address string, timeout time.Duration) (*ClamAVScanner, error) {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("CLAMAV_ADDRESS inválido: %v", err)
+	}
+
+	switch parsed.Scheme {
+	case "tcp":
+		return &ClamAVScanner{network: "tcp", address: parsed.Host, timeout: timeout}, nil
+	case "unix":
+		return &ClamAVScanner{network: "unix", address: parsed.Path, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("CLAMAV_ADDRESS deve começar com tcp:// ou unix://")
+	}
+}
+
+// Scan envia o conteúdo ao clamd e interpreta a resposta ("stream: OK" ou "stream: <assinatura> FOUND")
+func (s *ClamAVScanner) Scan(ctx context.Context, reader io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("erro ao conectar ao clamd: %v", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, err
+	}
+
+	// Cada bloco é precedido do tamanho em 4 bytes (big-endian); um bloco vazio encerra o envio
+	buffer := make([]byte, clamavChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := reader.Read(buffer)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return Result{}, err
+			}
+			if _, err := conn.Write(buffer[:n]); err != nil {
+				return Result{}, err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return Result{}, err
+	}
+
+	response, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return Result{}, fmt.Errorf("erro ao ler resposta do clamd: %v", err)
+	}
+
+	return parseClamAVResponse(strings.TrimRight(response, "\x00\n"))
+}
+
+// parseClamAVResponse interpreta a resposta do comando INSTREAM
+func parseClamAVResponse(response string) (Result, error) {
+	response = strings.TrimPrefix(response, "stream: ")
+
+	switch {
+	case response == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(response, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(response, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("resposta inesperada do clamd: %q", response)
+	}
+}
@@ -0,0 +1,33 @@
+package scannerProvider
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// eicarSignature é o trecho do arquivo de teste EICAR, reconhecido por todos os antivírus
+const eicarSignature = "EICAR-STANDARD-ANTIVIRUS-TEST-FILE"
+
+// FakeScanner marca como infectado qualquer arquivo que contenha o padrão de teste EICAR.
+// Útil em testes e em desenvolvimento, sem precisar do clamd.
+type FakeScanner struct{}
+
+// NewFakeScanner cria o scanner falso
+func NewFakeScanner() *FakeScanner {
+	return &FakeScanner{}
+}
+
+// Scan lê todo o conteúdo e procura o padrão EICAR
+func (s *FakeScanner) Scan(ctx context.Context, reader io.Reader) (Result, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if bytes.Contains(content, []byte(eicarSignature)) {
+		return Result{Signature: "Eicar-Test-Signature"}, nil
+	}
+
+	return Result{Clean: true}, nil
+}
@@ -0,0 +1,72 @@
+package scannerProvider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"sixTask/config/appConfig"
+)
+
+// Drivers disponíveis em SCANNER_DRIVER
+const (
+	DriverNone   = "none"
+	DriverClamAV = "clamav"
+	DriverFake   = "fake"
+)
+
+// Result é o resultado da verificação de um arquivo
+type Result struct {
+	Clean bool
+	// Signature é o nome da ameaça encontrada quando Clean é false
+	Signature string
+}
+
+// Scanner verifica o conteúdo de um arquivo em busca de vírus
+type Scanner interface {
+	Scan(ctx context.Context, reader io.Reader) (Result, error)
+}
+
+var (
+	scannerMu   sync.Mutex
+	scanner     Scanner
+	scannerErr  error
+	scannerInit bool
+)
+
+// Default retorna o scanner configurado, criando-o no primeiro uso.
+// Retorna nil quando a verificação está desativada (SCANNER_DRIVER=none).
+func Default() (Scanner, error) {
+	scannerMu.Lock()
+	defer scannerMu.Unlock()
+
+	if !scannerInit {
+		scanner, scannerErr = New(appConfig.Get().Scanner)
+		scannerInit = true
+	}
+
+	return scanner, scannerErr
+}
+
+// SetDefault substitui o scanner em uso, por exemplo pelo FakeScanner em testes
+func SetDefault(s Scanner) {
+	scannerMu.Lock()
+	defer scannerMu.Unlock()
+
+	scanner, scannerErr, scannerInit = s, nil, true
+}
+
+// New cria o scanner indicado em cfg.Driver (none, clamav ou fake)
+func New(cfg appConfig.ScannerConfig) (Scanner, error) {
+	switch cfg.Driver {
+	case "", DriverNone:
+		return nil, nil
+	case DriverClamAV:
+		return NewClamAVScanner(cfg.ClamAVAddress, cfg.ClamAVTimeout)
+	case DriverFake:
+		return NewFakeScanner(), nil
+	default:
+		return nil, fmt.Errorf("SCANNER_DRIVER inválido: %q", cfg.Driver)
+	}
+}
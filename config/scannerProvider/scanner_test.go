@@ -0,0 +1,68 @@
+package scannerProvider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"sixTask/config/appConfig"
+)
+
+func TestFakeScanner(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantClean     bool
+		wantSignature string
+	}{
+		{"arquivo limpo", "relatório de entregas", true, ""},
+		{"arquivo vazio", "", true, ""},
+		{"arquivo EICAR", `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`, false, "Eicar-Test-Signature"},
+		{"EICAR no meio do arquivo", "início " + eicarSignature + " fim", false, "Eicar-Test-Signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewFakeScanner().Scan(context.Background(), strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("Scan() erro inesperado: %v", err)
+			}
+			if result.Clean != tt.wantClean || result.Signature != tt.wantSignature {
+				t.Errorf("Scan() = %+v, esperado Clean=%v Signature=%q", result, tt.wantClean, tt.wantSignature)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     appConfig.ScannerConfig
+		want    string
+		wantErr bool
+	}{
+		{"desativado por padrão", appConfig.ScannerConfig{}, "<nil>", false},
+		{"none", appConfig.ScannerConfig{Driver: DriverNone}, "<nil>", false},
+		{"fake", appConfig.ScannerConfig{Driver: DriverFake}, "*scannerProvider.FakeScanner", false},
+		{"driver desconhecido", appConfig.ScannerConfig{Driver: "virustotal"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("New() = %T, esperado erro", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() erro inesperado: %v", err)
+			}
+			if gotType := fmt.Sprintf("%T", got); gotType != tt.want {
+				t.Errorf("New() = %s, esperado %s", gotType, tt.want)
+			}
+		})
+	}
+}
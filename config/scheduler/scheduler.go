@@ -6,15 +6,15 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
-)
 
-// Configuração do Redis para o scheduler
-const redisAddr = "localhost:6379"
+	"sixTask/config/queue"
+	"sixTask/internal/service/attachmentService"
+)
 
 // SetupScheduler configura e inicia o scheduler
 func SetupScheduler() {
 	// Cria uma nova instância do scheduler
-	taskScheduler := NewScheduler(redisAddr)
+	taskScheduler := NewScheduler(queue.RedisOpt())
 
 	// Registra as tarefas agendadas
 	registerTasks(taskScheduler)
@@ -60,6 +60,9 @@ func SetupScheduler() {
 
 // registerTasks registra todas as tarefas agendadas
 func registerTasks(ts *TaskScheduler) {
+	// Remove as sessões de upload em blocos expiradas e os blocos já gravados no storage
+	ts.Register(attachmentService.NewCleanupUploadsTask()).Hourly()
+
 	//// Exemplo de tarefa agendada para executar a cada minuto
 	//examplePayload := RequestModel.Pessoa{
 	//	Nome:  "Exemplo",
@@ -67,7 +70,7 @@ func registerTasks(ts *TaskScheduler) {
 	//	Idade: 30,
 	//}
 	//
-	//task, _ := jobs.NewJobModel(examplePayload)
+	//task, _ := jobs.NewJobModel(context.Background(), examplePayload, 0)
 	//ts.Register(task).EveryThirtyMinutes()
 
 	// Aqui você pode registrar outras tarefas com diferentes intervalos
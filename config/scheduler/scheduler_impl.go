@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/hibiken/asynq"
+
+	"sixTask/config/logger"
 )
 
 // Schedule representa uma configuração de agendamento para uma tarefa
@@ -20,12 +22,12 @@ type TaskScheduler struct {
 	scheduler *asynq.Scheduler
 }
 
-// NewScheduler cria uma nova instância do TaskScheduler
-func NewScheduler(redisAddr string) *TaskScheduler {
-	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddr})
+// NewScheduler cria uma nova instância do TaskScheduler conectada ao Redis informado
+func NewScheduler(redisOpt asynq.RedisConnOpt) *TaskScheduler {
+	client := asynq.NewClient(redisOpt)
 	scheduler := asynq.NewScheduler(
-		asynq.RedisClientOpt{Addr: redisAddr},
-		&asynq.SchedulerOpts{},
+		redisOpt,
+		&asynq.SchedulerOpts{Logger: logger.AsynqLogger{}},
 	)
 
 	return &TaskScheduler{
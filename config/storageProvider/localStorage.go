@@ -0,0 +1,193 @@
+package storageProvider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	signedurl "sixTask/helpers/signedUrl"
+)
+
+// LocalStorage guarda os arquivos em um diretório do disco local
+type LocalStorage struct {
+	root      string
+	publicURL string
+	urlTTL    time.Duration
+}
+
+// NewLocalStorage cria o driver local com a raiz e o prefixo público informados.
+// As URLs geradas são assinadas e valem por urlTTL.
+func NewLocalStorage(root, publicURL string, urlTTL time.Duration) *LocalStorage {
+	return &LocalStorage{
+		root:      root,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		urlTTL:    urlTTL,
+	}
+}
+
+// path converte a chave em um caminho dentro da raiz do storage
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Put grava o conteúdo do reader, criando os diretórios necessários. O conteúdo é gravado em um
+// arquivo temporário no mesmo diretório e renomeado ao final, de modo que leitores nunca vejam um
+// arquivo pela metade e uma falha não apague a versão anterior.
+func (s *LocalStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(fullPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, "."+filepath.Base(fullPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// CreateTemp cria o arquivo com permissão 0600; os arquivos do storage seguem a de os.Create
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, fullPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// Get abre o arquivo para leitura
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Diretórios não são arquivos do storage, como em Stat
+	if info, err := file.Stat(); err != nil || info.IsDir() {
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrNotFound
+	}
+
+	return file, nil
+}
+
+// Delete remove o arquivo; remover um arquivo inexistente não é erro
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// Exists informa se o arquivo existe
+func (s *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.Stat(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Stat retorna os dados do arquivo; o tipo é deduzido pela extensão
+func (s *LocalStorage) Stat(ctx context.Context, key string) (FileInfo, error) {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	info, err := os.Stat(fullPath)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return FileInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	cleaned, _ := CleanKey(key)
+	return FileInfo{
+		Key:         cleaned,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(cleaned)),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// URL retorna o endereço assinado servido pela rota /storage
+func (s *LocalStorage) URL(ctx context.Context, key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	query := signedurl.Sign(StorageResource(cleaned), time.Now().Add(s.urlTTL))
+	return s.publicURL + "/" + cleaned + "?" + query.Encode(), nil
+}
+
+// Ping verifica se a raiz existe e aceita gravação, criando-a se necessário
+func (s *LocalStorage) Ping(ctx context.Context) error {
+	if err := os.MkdirAll(s.root, 0755); err != nil {
+		return err
+	}
+
+	probe, err := os.CreateTemp(s.root, ".ping-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+
+	return os.Remove(probe.Name())
+}
+
+// StorageResource identifica a chave nas assinaturas dos links da rota /storage
+func StorageResource(key string) string {
+	return "storage:" + key
+}
@@ -0,0 +1,90 @@
+package storageProvider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sixTask/config/appConfig"
+	signedurl "sixTask/helpers/signedUrl"
+)
+
+func TestLocalStorage(t *testing.T) {
+	testDriver(t, NewLocalStorage(t.TempDir(), "/storage", time.Minute), "attachments")
+}
+
+// failingReader devolve parte do conteúdo e depois um erro, como uma conexão interrompida
+type failingReader struct {
+	sent bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("conexão interrompida")
+	}
+	r.sent = true
+	return copy(p, "conteúdo pela metade"), nil
+}
+
+func TestLocalStoragePutIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalStorage(root, "/storage", time.Minute)
+
+	if err := store.Put(ctx, "docs/relatorio.txt", strings.NewReader("versão completa"), -1, "text/plain"); err != nil {
+		t.Fatalf("Put() erro inesperado: %v", err)
+	}
+
+	if err := store.Put(ctx, "docs/relatorio.txt", &failingReader{}, -1, "text/plain"); err == nil {
+		t.Fatal("Put() deveria falhar quando o reader falha")
+	}
+
+	reader, err := store.Get(ctx, "docs/relatorio.txt")
+	if err != nil {
+		t.Fatalf("Get() erro inesperado: %v", err)
+	}
+	body, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(body) != "versão completa" {
+		t.Errorf("Get() = %q, a versão anterior deveria ser mantida", body)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "docs"))
+	if err != nil {
+		t.Fatalf("ReadDir() erro inesperado: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("o diretório deveria conter apenas o arquivo gravado, contém %d entradas", len(entries))
+	}
+}
+
+func TestLocalStorageURL(t *testing.T) {
+	appConfig.Set(&appConfig.Config{Auth: appConfig.AuthConfig{Secret: "segredo-de-teste"}})
+	store := NewLocalStorage(t.TempDir(), "/storage/", time.Minute)
+
+	link, err := store.URL(context.Background(), "/docs//relatorio.txt")
+	if err != nil {
+		t.Fatalf("URL() erro inesperado: %v", err)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("URL() = %q, endereço inválido: %v", link, err)
+	}
+	if parsed.Path != "/storage/docs/relatorio.txt" {
+		t.Errorf("URL() caminho = %q", parsed.Path)
+	}
+	if err := signedurl.Verify(StorageResource("docs/relatorio.txt"), parsed.Query()); err != nil {
+		t.Errorf("URL() deveria gerar um link assinado válido: %v", err)
+	}
+
+	if _, err := store.URL(context.Background(), "../segredo"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("URL() com chave inválida erro = %v, esperado ErrInvalidKey", err)
+	}
+}
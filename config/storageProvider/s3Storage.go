@@ -0,0 +1,167 @@
+package storageProvider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"sixTask/config/appConfig"
+)
+
+// S3Storage guarda os arquivos em um bucket compatível com S3 (AWS, MinIO, etc.)
+type S3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+	urlTTL    time.Duration
+}
+
+// NewS3StorageFromConfig cria o driver S3 a partir da configuração do bucket
+func NewS3StorageFromConfig(cfg appConfig.S3Config) (*S3Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3_ENDPOINT e S3_BUCKET são obrigatórios para o driver s3")
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar o cliente S3: %v", err)
+	}
+
+	return NewS3Storage(client, cfg.Bucket, cfg.PublicURL, cfg.URLTTL), nil
+}
+
+// NewS3Storage cria o driver S3 com um cliente já configurado.
+// Com publicURL vazio, URL gera links pré-assinados válidos por urlTTL.
+func NewS3Storage(client *minio.Client, bucket, publicURL string, urlTTL time.Duration) *S3Storage {
+	return &S3Storage{
+		client:    client,
+		bucket:    bucket,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		urlTTL:    urlTTL,
+	}
+}
+
+// Put envia o conteúdo do reader para o bucket
+func (s *S3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucket, cleaned, reader, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Get abre o objeto para leitura
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, cleaned, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, translateS3Error(err)
+	}
+
+	// GetObject só acessa o bucket na primeira leitura; Stat antecipa o erro de objeto inexistente
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, translateS3Error(err)
+	}
+
+	return object, nil
+}
+
+// Delete remove o objeto; remover um objeto inexistente não é erro
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, cleaned, minio.RemoveObjectOptions{})
+}
+
+// Exists informa se o objeto existe
+func (s *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.Stat(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Stat retorna os dados do objeto
+func (s *S3Storage) Stat(ctx context.Context, key string) (FileInfo, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	info, err := s.client.StatObject(ctx, s.bucket, cleaned, minio.StatObjectOptions{})
+	if err != nil {
+		return FileInfo{}, translateS3Error(err)
+	}
+
+	return FileInfo{
+		Key:         cleaned,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+// Ping verifica se o bucket existe e se as credenciais dão acesso a ele
+func (s *S3Storage) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s não encontrado", s.bucket)
+	}
+
+	return nil
+}
+
+// URL retorna o endereço público do objeto ou um link pré-assinado
+func (s *S3Storage) URL(ctx context.Context, key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if s.publicURL != "" {
+		return s.publicURL + "/" + cleaned, nil
+	}
+
+	signed, err := s.client.PresignedGetObject(ctx, s.bucket, cleaned, s.urlTTL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return signed.String(), nil
+}
+
+// translateS3Error converte a resposta de objeto inexistente em ErrNotFound
+func translateS3Error(err error) error {
+	response := minio.ToErrorResponse(err)
+	if response.Code == "NoSuchKey" || response.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,57 @@
+package storageProvider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// TestS3Storage roda contra um MinIO local (ex.: o serviço minio do docker-compose) quando
+// S3_TEST_ENDPOINT está definido. O bucket S3_TEST_BUCKET (padrão sixtask-test) é criado se não existir.
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT não definido; informe o endereço de um MinIO (ex.: localhost:9000) para testar o driver s3")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds: credentials.NewStaticV4(
+			envOr("S3_TEST_ACCESS_KEY", "minioadmin"),
+			envOr("S3_TEST_SECRET_KEY", "minioadmin"),
+			"",
+		),
+		Secure: os.Getenv("S3_TEST_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatalf("erro ao criar o cliente S3: %v", err)
+	}
+
+	ctx := context.Background()
+	bucket := envOr("S3_TEST_BUCKET", "sixtask-test")
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		t.Fatalf("erro ao acessar o MinIO em %s: %v", endpoint, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			t.Fatalf("erro ao criar o bucket %s: %v", bucket, err)
+		}
+	}
+
+	// Cada execução usa um prefixo próprio para não interferir em outras
+	prefix := fmt.Sprintf("storage-test-%d", time.Now().UnixNano())
+	testDriver(t, NewS3Storage(client, bucket, "", time.Minute), prefix)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,112 @@
+package storageProvider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"sixTask/config/appConfig"
+)
+
+// Drivers disponíveis em STORAGE_DRIVER
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	// ErrNotFound indica que o arquivo não existe no storage
+	ErrNotFound = errors.New("arquivo não encontrado")
+	// ErrInvalidKey indica uma chave vazia ou que tenta sair da raiz do storage
+	ErrInvalidKey = errors.New("caminho de arquivo inválido")
+)
+
+// FileInfo descreve um arquivo armazenado
+type FileInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage abstrai onde os arquivos são guardados. As chaves são caminhos relativos
+// separados por "/" (ex.: "attachments/2025/05/arquivo.pdf").
+type Storage interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	// Get abre o arquivo para leitura; quem chama deve fechá-lo. Os drivers atuais
+	// também implementam io.Seeker, o que permite respostas com Range.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	Stat(ctx context.Context, key string) (FileInfo, error)
+	// URL retorna o endereço público (ou pré-assinado) para download do arquivo
+	URL(ctx context.Context, key string) (string, error)
+	// Ping verifica se o storage está acessível, usado pela rota /readyz
+	Ping(ctx context.Context) error
+}
+
+var (
+	storageMu   sync.Mutex
+	storage     Storage
+	storageErr  error
+	storageInit bool
+)
+
+// Default retorna o storage configurado, criando-o no primeiro uso
+func Default() (Storage, error) {
+	storageMu.Lock()
+	defer storageMu.Unlock()
+
+	if !storageInit {
+		storage, storageErr = New(appConfig.Get().Storage)
+		storageInit = true
+	}
+
+	return storage, storageErr
+}
+
+// SetDefault substitui o storage em uso, por exemplo por um diretório temporário em testes
+func SetDefault(s Storage) {
+	storageMu.Lock()
+	defer storageMu.Unlock()
+
+	storage, storageErr, storageInit = s, nil, true
+}
+
+// New cria o storage indicado em cfg.Driver (local ou s3)
+func New(cfg appConfig.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		root := cfg.LocalPath
+		if root == "" {
+			root = StorageBasePath
+		}
+		return NewLocalStorage(root, cfg.PublicURL, cfg.URLTTL), nil
+	case DriverS3:
+		return NewS3StorageFromConfig(cfg.S3)
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER inválido: %q", cfg.Driver)
+	}
+}
+
+// CleanKey normaliza a chave e rejeita caminhos absolutos ou com ".."
+func CleanKey(key string) (string, error) {
+	key = strings.ReplaceAll(key, "\\", "/")
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" {
+		return "", ErrInvalidKey
+	}
+
+	return strings.TrimPrefix(cleaned, "/"), nil
+}
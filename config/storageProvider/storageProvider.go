@@ -3,20 +3,22 @@ package storageProvider
 import (
 	"crypto/rand"
 	"encoding/hex"
-
-	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 const (
+	// StorageBasePath é a raiz padrão do driver local (STORAGE_LOCAL_PATH)
 	StorageBasePath = "storage/app"
 )
 
-// SaveFile saves a file to storage/app directory with subdirectory path if provided
-// Returns the random filename and path where file is stored
-func SaveFile(c *gin.Context, fileField string, subPath string) (string, string, error) {
+// SaveFile saves the uploaded file to the configured storage under subPath
+// Returns the random filename and the storage key where file is stored.
+// O arquivo é recusado com ErrFileTooLarge ou ErrTypeNotAllowed se violar a política.
+func SaveFile(c *gin.Context, fileField string, subPath string, policy UploadPolicy) (string, string, error) {
 	// Get file from form
 	file, err := c.FormFile(fileField)
 	if err != nil {
@@ -24,30 +26,44 @@ func SaveFile(c *gin.Context, fileField string, subPath string) (string, string,
 	}
 
 	// Generate random filename
-	randomName, err := generateRandomFilename(filepath.Ext(file.Filename))
+	key, err := NewKey(subPath, file.Filename)
 	if err != nil {
 		return "", "", err
 	}
 
-	// Create full path with subdirectories
-	fullPath := filepath.Join(StorageBasePath, subPath)
+	store, err := Default()
+	if err != nil {
+		return "", "", err
+	}
 
-	// Create directories if they don't exist
-	if err := os.MkdirAll(fullPath, 0755); err != nil {
+	src, err := file.Open()
+	if err != nil {
 		return "", "", err
 	}
+	defer src.Close()
 
-	// Full path with filename
-	fullFilePath := filepath.Join(fullPath, randomName)
+	// Validate size and type before touching the storage
+	contentType, err := policy.Check(src, file.Size)
+	if err != nil {
+		return "", "", err
+	}
 
 	// Save the file
-	if err := c.SaveUploadedFile(file, fullFilePath); err != nil {
+	if err := store.Put(c.Request.Context(), key, src, file.Size, contentType); err != nil {
 		return "", "", err
 	}
 
-	// Return random filename and relative path
-	relativePath := filepath.Join(subPath, randomName)
-	return fullFilePath, relativePath, nil
+	return path.Base(key), key, nil
+}
+
+// NewKey gera uma chave única em subPath mantendo a extensão do nome original
+func NewKey(subPath, originalName string) (string, error) {
+	randomName, err := generateRandomFilename(strings.ToLower(filepath.Ext(originalName)))
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(subPath, randomName), nil
 }
 
 // GenerateRandomFilename creates a random filename with the original extension
@@ -0,0 +1,133 @@
+package storageProvider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"chave simples", "attachments/2025/05/file.pdf", "attachments/2025/05/file.pdf", false},
+		{"barras repetidas e ponto", "attachments//./file.pdf", "attachments/file.pdf", false},
+		{"barra inicial", "/attachments/file.pdf", "attachments/file.pdf", false},
+		{"barra invertida", `attachments\file.pdf`, "attachments/file.pdf", false},
+		{"subida de diretório", "../etc/passwd", "", true},
+		{"subida no meio", "attachments/../../etc/passwd", "", true},
+		{"subida que voltaria para dentro", "attachments/../attachments/file.pdf", "", true},
+		{"subida com barra invertida", `attachments\..\..\etc\passwd`, "", true},
+		{"somente subida", "..", "", true},
+		{"vazia", "", "", true},
+		{"somente barras", "//", "", true},
+		{"somente ponto", ".", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CleanKey(tt.key)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("CleanKey(%q) erro = %v, esperado ErrInvalidKey", tt.key, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CleanKey(%q) erro inesperado: %v", tt.key, err)
+			}
+			if got != tt.want {
+				t.Errorf("CleanKey(%q) = %q, esperado %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// testDriver verifica o contrato de Storage comum a todos os drivers, usando chaves sob prefix
+func testDriver(t *testing.T, store Storage, prefix string) {
+	t.Helper()
+	ctx := context.Background()
+	key := prefix + "/docs/relatorio.txt"
+
+	if err := store.Put(ctx, key, strings.NewReader("primeira versão"), -1, "text/plain"); err != nil {
+		t.Fatalf("Put() erro inesperado: %v", err)
+	}
+	if err := store.Put(ctx, key, strings.NewReader("segunda versão"), int64(len("segunda versão")), "text/plain"); err != nil {
+		t.Fatalf("Put() sobre arquivo existente erro inesperado: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		wantExists bool
+		wantBody   string
+		wantErr    error
+	}{
+		{"arquivo gravado", key, true, "segunda versão", nil},
+		{"chave equivalente", "/" + prefix + "//docs/./relatorio.txt", true, "segunda versão", nil},
+		{"arquivo inexistente", prefix + "/docs/outro.txt", false, "", ErrNotFound},
+		{"diretório", prefix + "/docs", false, "", ErrNotFound},
+		{"chave inválida", "../" + key, false, "", ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := store.Exists(ctx, tt.key)
+			if tt.wantErr == ErrInvalidKey {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("Exists() erro = %v, esperado ErrInvalidKey", err)
+				}
+			} else if err != nil || exists != tt.wantExists {
+				t.Fatalf("Exists() = %v, %v, esperado %v", exists, err, tt.wantExists)
+			}
+
+			info, err := store.Stat(ctx, tt.key)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Stat() erro = %v, esperado %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Stat() erro inesperado: %v", err)
+				}
+				if info.Key != key || info.Size != int64(len(tt.wantBody)) || !strings.HasPrefix(info.ContentType, "text/plain") {
+					t.Errorf("Stat() = %+v", info)
+				}
+			}
+
+			reader, err := store.Get(ctx, tt.key)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Get() erro = %v, esperado %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() erro inesperado: %v", err)
+			}
+			body, err := io.ReadAll(reader)
+			reader.Close()
+			if err != nil || string(body) != tt.wantBody {
+				t.Errorf("Get() = %q, %v, esperado %q", body, err, tt.wantBody)
+			}
+		})
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() erro inesperado: %v", err)
+	}
+	if exists, err := store.Exists(ctx, key); err != nil || exists {
+		t.Errorf("Exists() depois de Delete() = %v, %v", exists, err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() de arquivo inexistente erro = %v, esperado nil", err)
+	}
+	if err := store.Ping(ctx); err != nil {
+		t.Errorf("Ping() erro inesperado: %v", err)
+	}
+}
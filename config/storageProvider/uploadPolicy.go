@@ -0,0 +1,156 @@
+package storageProvider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"sixTask/config/appConfig"
+)
+
+// sniffLength é a quantidade de bytes lida para identificar o tipo, a mesma usada pelo mimetype
+const sniffLength = 3072
+
+var (
+	// ErrFileTooLarge indica que o arquivo excede o tamanho máximo da política
+	ErrFileTooLarge = errors.New("arquivo excede o tamanho máximo permitido")
+	// ErrTypeNotAllowed indica que o tipo do arquivo não está na lista de tipos permitidos
+	ErrTypeNotAllowed = errors.New("tipo de arquivo não permitido")
+)
+
+// UploadPolicy define o tamanho máximo e os tipos MIME aceitos em um upload.
+// AllowedTypes aceita curingas como "image/*"; vazio aceita qualquer tipo.
+type UploadPolicy struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// DefaultUploadPolicy retorna a política usada quando nenhuma outra é informada
+// (UPLOAD_MAX_SIZE, padrão 20MB, e UPLOAD_ALLOWED_TYPES, lista separada por vírgulas)
+func DefaultUploadPolicy() UploadPolicy {
+	cfg := appConfig.Get().Upload
+
+	return UploadPolicy{
+		MaxSize:      cfg.MaxSize,
+		AllowedTypes: cfg.AllowedTypes,
+	}
+}
+
+// Check valida o tamanho e identifica o tipo do arquivo pelo conteúdo, ignorando o
+// Content-Type enviado pelo cliente. O leitor volta ao início para ser gravado em seguida.
+func (p UploadPolicy) Check(file io.ReadSeeker, size int64) (string, error) {
+	if err := p.checkSize(size); err != nil {
+		return "", err
+	}
+
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return p.checkType(detected.String())
+}
+
+// CheckStream faz a mesma validação de Check para leitores sem Seek, como arquivos montados
+// a partir de blocos. Retorna um leitor que reproduz o conteúdo já lido para a identificação.
+func (p UploadPolicy) CheckStream(reader io.Reader, size int64) (string, io.Reader, error) {
+	if err := p.checkSize(size); err != nil {
+		return "", nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	contentType, err := p.checkType(mimetype.Detect(head).String())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return contentType, io.MultiReader(bytes.NewReader(head), reader), nil
+}
+
+// checkSize recusa arquivos maiores que MaxSize
+func (p UploadPolicy) checkSize(size int64) error {
+	if p.MaxSize > 0 && size > p.MaxSize {
+		return fmt.Errorf("%w (%s)", ErrFileTooLarge, FormatSize(p.MaxSize))
+	}
+
+	return nil
+}
+
+// checkType recusa tipos fora de AllowedTypes
+func (p UploadPolicy) checkType(contentType string) (string, error) {
+	if !p.Allows(contentType) {
+		return "", fmt.Errorf("%w: %s", ErrTypeNotAllowed, contentType)
+	}
+
+	return contentType, nil
+}
+
+// Allows informa se o tipo MIME está na lista de tipos permitidos.
+// A comparação usa apenas o tipo base, sem parâmetros como charset.
+func (p UploadPolicy) Allows(contentType string) bool {
+	if len(p.AllowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range p.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+			continue
+		}
+		if mediaType == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatSize apresenta um tamanho em bytes na maior unidade possível (ex.: 20MB, 1.5GB)
+func FormatSize(size int64) string {
+	for _, unit := range sizeUnits {
+		if unit.bytes == 1 || size < unit.bytes {
+			continue
+		}
+		if size%unit.bytes == 0 {
+			return strconv.FormatInt(size/unit.bytes, 10) + unit.suffix
+		}
+		return strconv.FormatFloat(float64(size)/float64(unit.bytes), 'f', 1, 64) + unit.suffix
+	}
+
+	return strconv.FormatInt(size, 10) + "B"
+}
+
+// sizeUnits lista as unidades usadas em FormatSize, da maior para a menor
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
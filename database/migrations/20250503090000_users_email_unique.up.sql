@@ -0,0 +1,6 @@
+-- Os emails passam a ser salvos em minúsculas; duplicados precisam ser resolvidos antes desta migration
+UPDATE users
+SET email = LOWER(TRIM(email));
+
+ALTER TABLE users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP;
+
+CREATE TABLE user_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type       VARCHAR(30) NOT NULL,
+    token_hash TEXT        NOT NULL UNIQUE,
+    expires_at TIMESTAMP   NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_tokens_user_type ON user_tokens (user_id, type);
@@ -0,0 +1,2 @@
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS checksum;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_attachments_user;
+
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS scanned_at,
+    DROP COLUMN IF EXISTS scan_result,
+    DROP COLUMN IF EXISTS status;
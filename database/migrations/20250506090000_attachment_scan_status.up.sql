@@ -0,0 +1,6 @@
+ALTER TABLE attachments
+    ADD COLUMN status      VARCHAR(20) NOT NULL DEFAULT 'clean' CHECK (status IN ('pending', 'clean', 'quarantined')),
+    ADD COLUMN scan_result TEXT,
+    ADD COLUMN scanned_at  TIMESTAMP;
+
+CREATE INDEX idx_attachments_user ON attachments (user_id);
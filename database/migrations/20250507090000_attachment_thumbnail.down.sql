@@ -0,0 +1,2 @@
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS thumbnail_generated_at;
@@ -0,0 +1,2 @@
+ALTER TABLE attachments
+    ADD COLUMN thumbnail_generated_at TIMESTAMP;
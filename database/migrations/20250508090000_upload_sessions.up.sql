@@ -0,0 +1,18 @@
+CREATE TABLE upload_sessions
+(
+    id              VARCHAR(32) PRIMARY KEY,
+    created_by      BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_id         BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    attachable_type TEXT        NOT NULL,
+    attachable_id   BIGINT      NOT NULL,
+    filename        TEXT        NOT NULL,
+    upload_length   BIGINT      NOT NULL,
+    upload_offset   BIGINT      NOT NULL DEFAULT 0,
+    chunk_keys      TEXT[]      NOT NULL DEFAULT '{}',
+    expires_at      TIMESTAMP   NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status          VARCHAR(20) NOT NULL DEFAULT 'uploading' CHECK (status IN ('uploading', 'finishing'))
+);
+
+CREATE INDEX idx_upload_sessions_expires ON upload_sessions (expires_at);
@@ -0,0 +1,18 @@
+CREATE TABLE exports
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    resource      VARCHAR(20) NOT NULL,
+    format        VARCHAR(10) NOT NULL CHECK (format IN ('xlsx', 'csv')),
+    filters       JSONB       NOT NULL DEFAULT '{}',
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    filename      TEXT,
+    filepath      TEXT,
+    row_count     INTEGER     NOT NULL DEFAULT 0,
+    error_message TEXT,
+    finished_at   TIMESTAMP,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exports_user ON exports (user_id);
@@ -20,8 +20,8 @@ SELECT * FROM attachments WHERE user_id = @user_id;
 SELECT * FROM attachments WHERE attachable_type = @attachable_type AND attachable_id = @attachable_id;
 
 -- name: CreateAttachment :one
-INSERT INTO attachments (filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id)
-VALUES (@filename, @filepath, @filesize, @filetype, @user_id, @attachable_type, @attachable_id) RETURNING *;
+INSERT INTO attachments (filename, filepath, filesize, filetype, checksum, status, user_id, attachable_type, attachable_id)
+VALUES (@filename, @filepath, @filesize, @filetype, @checksum, @status, @user_id, @attachable_type, @attachable_id) RETURNING *;
 
 -- name: UpdateAttachment :one
 UPDATE attachments
@@ -32,3 +32,43 @@ RETURNING *;
 -- name: DeleteAttachment :exec
 DELETE FROM attachments
 WHERE id = @id;
+
+-- name: DeleteAttachmentsByAttachable :many
+DELETE FROM attachments
+WHERE attachable_type = @attachable_type AND attachable_id = ANY(@attachable_ids::bigint[])
+RETURNING *;
+
+-- name: UpdateAttachmentScanResult :one
+UPDATE attachments
+SET status = @status, filepath = @filepath, scan_result = @scan_result, scanned_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
+
+-- name: SumAttachmentSizeByUser :one
+SELECT COALESCE(SUM(filesize), 0)::bigint AS total
+FROM attachments
+WHERE user_id = @user_id;
+
+-- name: SumAttachmentSizeByProject :one
+SELECT COALESCE(SUM(a.filesize), 0)::bigint AS total
+FROM attachments a
+WHERE (a.attachable_type = 'project' AND a.attachable_id = @project_id::bigint)
+   OR (a.attachable_type = 'task' AND a.attachable_id IN (SELECT t.id FROM tasks t WHERE t.project_id = @project_id::bigint))
+   OR (a.attachable_type = 'subtask' AND a.attachable_id IN (
+        SELECT s.id FROM subtasks s JOIN tasks t ON t.id = s.task_id WHERE t.project_id = @project_id::bigint
+   ));
+
+-- name: LockStorageQuota :exec
+SELECT pg_advisory_xact_lock(hashtextextended(@scope::text, 0));
+
+-- name: FindAttachableProjectId :one
+SELECT (CASE @attachable_type::text
+    WHEN 'project' THEN @attachable_id::bigint
+    WHEN 'task' THEN (SELECT t.project_id FROM tasks t WHERE t.id = @attachable_id::bigint)
+    WHEN 'subtask' THEN (SELECT t.project_id FROM subtasks s JOIN tasks t ON t.id = s.task_id WHERE s.id = @attachable_id::bigint)
+END)::bigint AS project_id;
+
+-- name: UpdateAttachmentThumbnail :exec
+UPDATE attachments
+SET thumbnail_generated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
@@ -32,3 +32,7 @@ RETURNING *;
 -- name: DeleteComment :exec
 DELETE FROM comments
 WHERE id = @id;
+
+-- name: DeleteCommentsByCommentable :exec
+DELETE FROM comments
+WHERE commentable_type = @commentable_type AND commentable_id = ANY(@commentable_ids::bigint[]);
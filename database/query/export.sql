@@ -0,0 +1,37 @@
+-- name: CreateExport :one
+INSERT INTO exports (user_id, resource, format, filters)
+VALUES (@user_id, @resource, @format, @filters) RETURNING *;
+
+-- name: FindExportById :one
+SELECT * FROM exports
+WHERE id = @id;
+
+-- name: FindExportsByUserWithPagination :many
+SELECT * FROM exports
+WHERE user_id = @user_id
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountExportsByUser :one
+SELECT COUNT(*) FROM exports
+WHERE user_id = @user_id;
+
+-- name: StartExport :one
+UPDATE exports
+SET status        = 'processing',
+    error_message = NULL,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
+
+-- name: FinishExport :one
+UPDATE exports
+SET status        = @status,
+    filename      = @filename,
+    filepath      = @filepath,
+    row_count     = @row_count,
+    error_message = @error_message,
+    finished_at   = CURRENT_TIMESTAMP,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
@@ -0,0 +1,53 @@
+-- name: CreateImport :one
+INSERT INTO imports (user_id, template_id, filename, filepath)
+VALUES (@user_id, @template_id, @filename, @filepath) RETURNING *;
+
+-- name: FindImportById :one
+SELECT * FROM imports
+WHERE id = @id;
+
+-- name: FindImportsWithPagination :many
+SELECT * FROM imports
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountImports :one
+SELECT COUNT(*) FROM imports;
+
+-- name: StartImport :one
+UPDATE imports
+SET status        = 'processing',
+    total_rows    = 0,
+    inserted_rows = 0,
+    updated_rows  = 0,
+    failed_rows   = 0,
+    report_path   = NULL,
+    error_message = NULL,
+    started_at    = CURRENT_TIMESTAMP,
+    finished_at   = NULL,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
+
+-- name: UpdateImportProgress :exec
+UPDATE imports
+SET total_rows    = @total_rows,
+    inserted_rows = @inserted_rows,
+    updated_rows  = @updated_rows,
+    failed_rows   = @failed_rows,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: FinishImport :one
+UPDATE imports
+SET status        = @status,
+    total_rows    = @total_rows,
+    inserted_rows = @inserted_rows,
+    updated_rows  = @updated_rows,
+    failed_rows   = @failed_rows,
+    report_path   = @report_path,
+    error_message = @error_message,
+    finished_at   = CURRENT_TIMESTAMP,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
@@ -47,3 +47,7 @@ RETURNING *;
 -- name: DeleteNotification :exec
 DELETE FROM notifications
 WHERE id = @id;
+
+-- name: DeleteNotificationsByNotifiable :exec
+DELETE FROM notifications
+WHERE notifiable_type = @notifiable_type AND notifiable_id = ANY(@notifiable_ids::bigint[]);
@@ -256,3 +256,12 @@ SELECT COUNT(DISTINCT p.id)
 FROM projects p
 JOIN project_user pu ON p.id = pu.project_id
 WHERE pu.user_id = @user_id;
+
+-- name: FindProjectIdsByClientId :many
+SELECT id FROM projects WHERE client_id = @client_id;
+
+-- name: IsProjectMember :one
+SELECT EXISTS (
+    SELECT 1 FROM project_user
+    WHERE project_id = @project_id AND user_id = @user_id
+);
@@ -0,0 +1,29 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at)
+VALUES (@user_id, @token_hash, @family_id, @expires_at) RETURNING *;
+
+-- name: FindRefreshTokenByHashForUpdate :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = @token_hash
+FOR UPDATE;
+
+-- name: RevokeRefreshToken :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP, replaced_by = @replaced_by
+WHERE id = @id;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE family_id = @family_id AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id AND revoked_at IS NULL;
+
+-- name: IsRefreshTokenFamilyActive :one
+SELECT EXISTS (
+    SELECT 1 FROM refresh_tokens
+    WHERE family_id = @family_id AND revoked_at IS NULL AND expires_at > @now
+);
@@ -42,3 +42,19 @@ RETURNING *;
 -- name: DeleteSubtask :exec
 DELETE FROM subtasks
 WHERE id = @id;
+
+-- name: FindSubtaskIdsByTaskIds :many
+SELECT id FROM subtasks WHERE task_id = ANY(@task_ids::bigint[]);
+
+-- name: IsSubtaskMember :one
+SELECT EXISTS (
+    SELECT 1 FROM subtasks s
+    JOIN tasks t ON t.id = s.task_id
+    WHERE s.id = @subtask_id
+      AND (
+        s.assigned_to = @user_id
+        OR t.assigned_to = @user_id
+        OR EXISTS (SELECT 1 FROM task_user tu WHERE tu.task_id = t.id AND tu.user_id = @user_id)
+        OR EXISTS (SELECT 1 FROM project_user pu WHERE pu.project_id = t.project_id AND pu.user_id = @user_id)
+      )
+);
@@ -57,3 +57,25 @@ SELECT u.* FROM users u
 JOIN tasks t ON t.assigned_to = u.id
 WHERE t.id IN (sqlc.slice('task_ids'))
 ORDER BY u.id;
+
+-- name: FindTaskIdsByProjectIds :many
+SELECT id FROM tasks WHERE project_id = ANY(@project_ids::bigint[]);
+
+-- name: CreateUserTask :exec
+insert into task_user (user_id, task_id)
+values (@user_id, @task_id);
+
+-- name: DeleteUserTasksByTaskIds :exec
+DELETE FROM task_user
+WHERE task_id = ANY(@task_ids::bigint[]);
+
+-- name: IsTaskMember :one
+SELECT EXISTS (
+    SELECT 1 FROM tasks t
+    WHERE t.id = @task_id
+      AND (
+        t.assigned_to = @user_id
+        OR EXISTS (SELECT 1 FROM task_user tu WHERE tu.task_id = t.id AND tu.user_id = @user_id)
+        OR EXISTS (SELECT 1 FROM project_user pu WHERE pu.project_id = t.project_id AND pu.user_id = @user_id)
+      )
+);
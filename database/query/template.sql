@@ -0,0 +1,35 @@
+-- name: FindTemplateById :one
+SELECT * FROM templates
+WHERE id = @id;
+
+-- name: FindTableColumns :many
+SELECT column_name::text AS column_name, data_type::text AS data_type, (is_nullable = 'YES')::boolean AS is_nullable
+FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = @table_name::text
+ORDER BY ordinal_position;
+
+-- name: FindTemplatesWithPagination :many
+SELECT * FROM templates
+ORDER BY titulo, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountTemplates :one
+SELECT COUNT(*) FROM templates;
+
+-- name: CreateTemplate :one
+INSERT INTO templates (titulo, tabela, coluna_identificacao, colunas)
+VALUES (@titulo, @tabela, @coluna_identificacao, @colunas) RETURNING *;
+
+-- name: UpdateTemplate :one
+UPDATE templates
+SET titulo               = @titulo,
+    tabela               = @tabela,
+    coluna_identificacao = @coluna_identificacao,
+    colunas              = @colunas,
+    updated_at           = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
+
+-- name: DeleteTemplate :exec
+DELETE FROM templates
+WHERE id = @id;
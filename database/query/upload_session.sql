@@ -0,0 +1,48 @@
+-- name: CreateUploadSession :one
+INSERT INTO upload_sessions (id, created_by, user_id, attachable_type, attachable_id, filename, upload_length, expires_at)
+VALUES (@id, @created_by, @user_id, @attachable_type, @attachable_id, @filename, @upload_length, @expires_at) RETURNING *;
+
+-- name: FindUploadSessionById :one
+SELECT * FROM upload_sessions
+WHERE id = @id;
+
+-- name: AppendUploadChunk :one
+UPDATE upload_sessions
+SET upload_offset = upload_offset + @chunk_size::bigint,
+    chunk_keys    = array_append(chunk_keys, @chunk_key::text),
+    expires_at    = @expires_at,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = @id AND status = 'uploading' AND upload_offset = @upload_offset AND upload_offset + @chunk_size::bigint <= upload_length
+RETURNING *;
+
+-- name: ClaimUploadSession :one
+UPDATE upload_sessions
+SET status     = 'finishing',
+    expires_at = @expires_at,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = 'uploading' AND upload_offset = upload_length AND expires_at > @now
+RETURNING *;
+
+-- name: ReleaseUploadSession :exec
+UPDATE upload_sessions
+SET status     = 'uploading',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = 'finishing';
+
+-- name: DeleteUploadSession :exec
+DELETE FROM upload_sessions
+WHERE id = @id;
+
+-- name: CancelUploadSession :one
+DELETE FROM upload_sessions
+WHERE id = @id AND status = 'uploading'
+RETURNING *;
+
+-- name: DeleteExpiredUploadSessions :many
+DELETE FROM upload_sessions
+WHERE id IN (SELECT id FROM upload_sessions
+             WHERE expires_at < @now
+             ORDER BY expires_at
+             LIMIT @limit_count FOR UPDATE SKIP LOCKED)
+  AND expires_at < @now
+RETURNING *;
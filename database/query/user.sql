@@ -4,7 +4,7 @@ FROM users
 WHERE id = @id;
 
 -- name: FindManyUserIds :many
-SELECT id, name, email, password
+SELECT *
 FROM users
 WHERE id = ANY(@ids::bigint[]);
 
@@ -30,19 +30,36 @@ SELECT COUNT(*)
 FROM users;
 
 -- name: CreateUser :one
-INSERT INTO users (name, email, password)
-VALUES (@name, @email, @password)
+INSERT INTO users (name, email, password, role)
+VALUES (@name, @email, @password, @role)
 RETURNING *;
 
 -- name: UpdateUser :one
 UPDATE users
 SET name     = @name,
     email    = @email,
-    password = @password
+    password = @password,
+    role     = @role
 WHERE id = @id
 RETURNING *;
 
--- name: DeleteUser :exec
+-- name: ExistsUserByEmail :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE email = @email AND id <> @ignore_id
+);
+
+-- name: DeleteUser :execrows
 DELETE
 FROM users
 WHERE id = @id;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = @password
+WHERE id = @id;
+
+-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, CURRENT_TIMESTAMP)
+WHERE id = @id;
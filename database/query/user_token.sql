@@ -0,0 +1,20 @@
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (user_id, type, token_hash, expires_at)
+VALUES (@user_id, @type, @token_hash, @expires_at)
+RETURNING *;
+
+-- name: FindUserTokenByHashForUpdate :one
+SELECT *
+FROM user_tokens
+WHERE token_hash = @token_hash AND type = @type
+FOR UPDATE;
+
+-- name: MarkUserTokenUsed :exec
+UPDATE user_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: InvalidateUserTokens :exec
+UPDATE user_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id AND type = @type AND used_at IS NULL;
@@ -2,8 +2,10 @@ CREATE TABLE users
 (
     id       BIGSERIAL PRIMARY KEY,
     name     TEXT NOT NULL,
-    email    TEXT NOT NULL,
-    password TEXT NOT NULL
+    email    TEXT NOT NULL UNIQUE,
+    password TEXT NOT NULL,
+    role     VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'manager', 'member')),
+    email_verified_at TIMESTAMP
 );
 
 CREATE TABLE clients
@@ -83,10 +85,16 @@ CREATE TABLE attachments
     attachable_type TEXT   NOT NULL,
     attachable_id   BIGINT NOT NULL,
     created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    checksum        VARCHAR(64),
+    status          VARCHAR(20) NOT NULL DEFAULT 'clean' CHECK (status IN ('pending', 'clean', 'quarantined')),
+    scan_result     TEXT,
+    scanned_at      TIMESTAMP,
+    thumbnail_generated_at TIMESTAMP
 );
 
 CREATE INDEX idx_attachments_attachable ON attachments (attachable_type, attachable_id);
+CREATE INDEX idx_attachments_user ON attachments (user_id);
 
 CREATE TABLE notifications
 (
@@ -119,3 +127,96 @@ create table task_user
     user_id BIGINT,
     task_id BIGINT
 );
+
+
+create table refresh_tokens
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT    NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash  TEXT      NOT NULL UNIQUE,
+    family_id   TEXT      NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    revoked_at  TIMESTAMP,
+    replaced_by BIGINT REFERENCES refresh_tokens (id) ON DELETE SET NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_user ON refresh_tokens (user_id);
+CREATE INDEX idx_refresh_tokens_family ON refresh_tokens (family_id);
+
+
+create table user_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type       VARCHAR(30) NOT NULL,
+    token_hash TEXT        NOT NULL UNIQUE,
+    expires_at TIMESTAMP   NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_tokens_user_type ON user_tokens (user_id, type);
+
+
+create table upload_sessions
+(
+    id              VARCHAR(32) PRIMARY KEY,
+    created_by      BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_id         BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    attachable_type TEXT        NOT NULL,
+    attachable_id   BIGINT      NOT NULL,
+    filename        TEXT        NOT NULL,
+    upload_length   BIGINT      NOT NULL,
+    upload_offset   BIGINT      NOT NULL DEFAULT 0,
+    chunk_keys      TEXT[]      NOT NULL DEFAULT '{}',
+    expires_at      TIMESTAMP   NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status          VARCHAR(20) NOT NULL DEFAULT 'uploading' CHECK (status IN ('uploading', 'finishing'))
+);
+
+CREATE INDEX idx_upload_sessions_expires ON upload_sessions (expires_at);
+
+
+create table imports
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    template_id   BIGINT REFERENCES templates (id) ON DELETE SET NULL,
+    filename      TEXT        NOT NULL,
+    filepath      TEXT        NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    total_rows    INTEGER     NOT NULL DEFAULT 0,
+    inserted_rows INTEGER     NOT NULL DEFAULT 0,
+    updated_rows  INTEGER     NOT NULL DEFAULT 0,
+    failed_rows   INTEGER     NOT NULL DEFAULT 0,
+    report_path   TEXT,
+    error_message TEXT,
+    started_at    TIMESTAMP,
+    finished_at   TIMESTAMP,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_imports_user ON imports (user_id);
+
+
+create table exports
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    resource      VARCHAR(20) NOT NULL,
+    format        VARCHAR(10) NOT NULL CHECK (format IN ('xlsx', 'csv')),
+    filters       JSONB       NOT NULL DEFAULT '{}',
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    filename      TEXT,
+    filepath      TEXT,
+    row_count     INTEGER     NOT NULL DEFAULT 0,
+    error_message TEXT,
+    finished_at   TIMESTAMP,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exports_user ON exports (user_id);
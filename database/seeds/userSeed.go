@@ -1,22 +1,53 @@
 package seeds
 
 import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+
 	authhelper "sixTask/helpers/authHelper"
 	"sixTask/internal/database"
+	"sixTask/internal/types/roleTypes"
 )
 
 func UserSeeder() {
 
-	passwordHashed, _ := authhelper.HashPassword("admin2024")
-
-	dbCoon, ctx := database.ConnectDB()
-	defer dbCoon.Close(ctx)
+	ctx := context.Background()
+	dbCoon, err := database.AcquireConn(ctx)
+	if err != nil {
+		log.Printf("Seed de usuários ignorado: %v", err)
+		return
+	}
+	defer dbCoon.Release()
 
 	query := database.New(dbCoon)
 
-	query.CreateUser(ctx, database.CreateUserParams{
+	// O seed roda a cada inicialização; o admin só é criado uma vez
+	_, err = query.FindByEmail(ctx, "admin@admin.com")
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("Erro ao buscar usuário admin: %v", err)
+		return
+	}
+
+	passwordHashed, err := authhelper.HashPassword("admin2024")
+	if err != nil {
+		log.Printf("Erro ao gerar hash da senha do admin: %v", err)
+		return
+	}
+
+	// O usuário inicial é o administrador do sistema
+	_, err = query.CreateUser(ctx, database.CreateUserParams{
 		Name:     "admin",
 		Email:    "admin@admin.com",
 		Password: passwordHashed,
+		Role:     roleTypes.Admin,
 	})
+	if err != nil {
+		log.Printf("Erro ao criar usuário admin: %v", err)
+	}
 }
@@ -1,12 +1,20 @@
 package authhelper
 
 import (
-	"os"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+
+	"sixTask/config/appConfig"
 )
 
+// Claims representa o conteúdo do access token.
+// O campo ID (jti) guarda o identificador da sessão, compartilhado pela família de refresh tokens.
 type Claims struct {
 	UserID int64 `json:"user_id"`
 	jwt.RegisteredClaims
@@ -22,9 +30,83 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
+// GetSecret retorna o segredo de assinatura dos tokens (SECRET, obrigatório na inicialização)
 func GetSecret() []byte {
-	secret := os.Getenv("SECRET")
+	return []byte(appConfig.Get().Auth.Secret)
+}
+
+// GetAccessTokenTTL retorna a duração do access token (JWT_ACCESS_TTL, padrão 15m)
+func GetAccessTokenTTL() time.Duration {
+	return appConfig.Get().Auth.AccessTokenTTL
+}
+
+// GetRefreshTokenTTL retorna a duração do refresh token (JWT_REFRESH_TTL, padrão 7 dias)
+func GetRefreshTokenTTL() time.Duration {
+	return appConfig.Get().Auth.RefreshTokenTTL
+}
+
+// GetPasswordResetTTL retorna a validade do link de redefinição de senha (PASSWORD_RESET_TTL, padrão 1h)
+func GetPasswordResetTTL() time.Duration {
+	return appConfig.Get().Auth.PasswordResetTTL
+}
+
+// GetEmailVerificationTTL retorna a validade do link de confirmação de email (EMAIL_VERIFICATION_TTL, padrão 48h)
+func GetEmailVerificationTTL() time.Duration {
+	return appConfig.Get().Auth.EmailVerificationTTL
+}
+
+// GenerateAccessToken gera um JWT HS256 de curta duração ligado à sessão informada
+func GenerateAccessToken(userID int64, sessionID string) (string, error) {
+	now := time.Now()
+
+	claims := &Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(GetAccessTokenTTL())),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(GetSecret())
+}
+
+// GenerateRandomToken gera um token opaco aleatório e o hash que deve ser persistido no banco
+func GenerateRandomToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	token := hex.EncodeToString(bytes)
+
+	return token, HashToken(token), nil
+}
+
+// NewSessionID gera o identificador de uma nova sessão (família de refresh tokens)
+func NewSessionID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// HashToken retorna o SHA-256 do token; apenas o hash é armazenado no banco
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
 
-	return []byte(secret)
+// SignToken retorna a assinatura HMAC-SHA256 do token com a chave da aplicação.
+// Usada nos tokens enviados por email: sem a chave não é possível gerar uma assinatura válida.
+func SignToken(token string) string {
+	mac := hmac.New(sha256.New, GetSecret())
+	mac.Write([]byte(token))
 
+	return hex.EncodeToString(mac.Sum(nil))
 }
@@ -0,0 +1,74 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	authhelper "sixTask/helpers/authHelper"
+)
+
+var (
+	// ErrInvalidSignature indica uma assinatura ausente ou que não confere com o recurso
+	ErrInvalidSignature = errors.New("assinatura inválida")
+	// ErrExpired indica um link assinado cuja validade já passou
+	ErrExpired = errors.New("link expirado")
+)
+
+// Sign retorna os parâmetros expires e signature que autorizam o acesso ao recurso até expiresAt.
+// O recurso identifica o que está sendo liberado, por exemplo "attachment:42".
+func Sign(resource string, expiresAt time.Time) url.Values {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return url.Values{
+		"expires":   {expires},
+		"signature": {signature(resource, expires)},
+	}
+}
+
+// Verify confere a assinatura e a validade dos parâmetros recebidos para o recurso
+func Verify(resource string, query url.Values) error {
+	expires := query.Get("expires")
+	given := query.Get("signature")
+	if expires == "" || given == "" {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(given), []byte(signature(resource, expires))) {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > unix {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// keyPurpose separa a chave dos links assinados da usada em outras assinaturas da aplicação,
+// como a dos tokens de user_tokens (authhelper.SignToken)
+const keyPurpose = "storage-url"
+
+// signingKey deriva da chave da aplicação a chave usada apenas pelos links assinados
+func signingKey() []byte {
+	mac := hmac.New(sha256.New, authhelper.GetSecret())
+	mac.Write([]byte(keyPurpose))
+
+	return mac.Sum(nil)
+}
+
+// signature assina o recurso junto com a validade, impedindo que o prazo seja alterado
+func signature(resource, expires string) string {
+	mac := hmac.New(sha256.New, signingKey())
+	mac.Write([]byte(resource + "|" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,64 @@
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"sixTask/config/appConfig"
+)
+
+func TestVerify(t *testing.T) {
+	appConfig.Set(&appConfig.Config{Auth: appConfig.AuthConfig{Secret: "segredo-de-teste"}})
+
+	const resource = "attachment:42"
+	valid := Sign(resource, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		resource string
+		query    url.Values
+		want     error
+	}{
+		{"assinatura válida", resource, valid, nil},
+		{"outro recurso", "attachment:43", valid, ErrInvalidSignature},
+		{"validade alterada", resource, url.Values{
+			"expires":   {"9999999999"},
+			"signature": {valid.Get("signature")},
+		}, ErrInvalidSignature},
+		{"assinatura alterada", resource, url.Values{
+			"expires":   {valid.Get("expires")},
+			"signature": {tamper(valid.Get("signature"))},
+		}, ErrInvalidSignature},
+		{"sem assinatura", resource, url.Values{"expires": {valid.Get("expires")}}, ErrInvalidSignature},
+		{"sem validade", resource, url.Values{"signature": {valid.Get("signature")}}, ErrInvalidSignature},
+		{"sem parâmetros", resource, url.Values{}, ErrInvalidSignature},
+		{"expirado", resource, Sign(resource, time.Now().Add(-time.Minute)), ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.resource, tt.query); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, esperado %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSigningKeyIsDerived(t *testing.T) {
+	appConfig.Set(&appConfig.Config{Auth: appConfig.AuthConfig{Secret: "segredo-de-teste"}})
+
+	if string(signingKey()) == "segredo-de-teste" {
+		t.Fatal("signingKey() deveria derivar uma chave própria em vez de usar o segredo da aplicação")
+	}
+}
+
+// tamper troca o primeiro caractere da assinatura, mantendo o tamanho
+func tamper(signature string) string {
+	if signature[0] == '0' {
+		return "1" + signature[1:]
+	}
+
+	return "0" + signature[1:]
+}
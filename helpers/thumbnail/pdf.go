@@ -0,0 +1,48 @@
+package thumbnail
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func init() {
+	// Evita que o pdfcpu crie o diretório de configuração do usuário
+	api.DisableConfigDir()
+}
+
+// decodePDF extrai a maior imagem da primeira página do PDF
+func decodePDF(reader io.ReadSeeker) (image.Image, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+
+	pages, err := api.ExtractImagesRaw(reader, []string{"1"}, conf)
+	if err != nil {
+		return nil, fmt.Errorf("PDF inválido: %w", err)
+	}
+
+	var largest *model.Image
+	for _, images := range pages {
+		for _, img := range images {
+			if img.IsImgMask || (largest != nil && img.Width*img.Height <= largest.Width*largest.Height) {
+				continue
+			}
+			img := img
+			largest = &img
+		}
+	}
+	if largest == nil {
+		return nil, ErrNoPreview
+	}
+
+	content, err := io.ReadAll(largest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeImage(bytes.NewReader(content))
+}
@@ -0,0 +1,141 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"mime"
+	"strings"
+
+	// Decodificadores registrados em image.Decode
+	_ "image/gif"
+	_ "image/png"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// ContentType é o tipo das miniaturas geradas
+const ContentType = "image/jpeg"
+
+// maxPixels limita a resolução das imagens decodificadas, evitando estouro de memória
+// com arquivos pequenos que descrevem imagens enormes
+const maxPixels = 50_000_000
+
+// jpegQuality é a qualidade das miniaturas geradas
+const jpegQuality = 82
+
+var (
+	// ErrUnsupported indica um tipo de arquivo para o qual não há miniatura
+	ErrUnsupported = errors.New("tipo de arquivo sem suporte a miniatura")
+	// ErrNoPreview indica um PDF sem imagens na primeira página
+	ErrNoPreview = errors.New("arquivo sem imagem para a miniatura")
+	// ErrImageTooLarge indica uma imagem com resolução acima do limite
+	ErrImageTooLarge = errors.New("imagem com resolução acima do limite")
+)
+
+// Sizes são os tamanhos gerados, pelo maior lado em pixels
+var Sizes = map[string]int{
+	"small":  160,
+	"medium": 480,
+}
+
+// DefaultSize é o tamanho usado quando nenhum é informado
+const DefaultSize = "small"
+
+// Supports informa se há miniatura para o tipo de arquivo
+func Supports(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "image/jpeg", "image/png", "image/gif", "image/webp", "image/bmp", "image/tiff", "application/pdf":
+		return true
+	default:
+		return false
+	}
+}
+
+// Decode lê a imagem de origem da miniatura. Para PDFs usa a primeira imagem
+// da primeira página, já que não há renderização de páginas em Go puro.
+func Decode(reader io.ReadSeeker, contentType string) (image.Image, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/pdf":
+		return decodePDF(reader)
+	case Supports(mediaType):
+		return decodeImage(reader)
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+// Generate redimensiona a imagem para cada tamanho em Sizes e retorna os JPEGs gerados
+func Generate(img image.Image) (map[string][]byte, error) {
+	thumbnails := make(map[string][]byte, len(Sizes))
+
+	for name, maxSide := range Sizes {
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, Resize(img, maxSide), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("erro ao gerar miniatura %s: %w", name, err)
+		}
+		thumbnails[name] = buffer.Bytes()
+	}
+
+	return thumbnails, nil
+}
+
+// Resize reduz a imagem para que o maior lado tenha no máximo maxSide pixels, mantendo a proporção.
+// Imagens menores não são ampliadas. Áreas transparentes ficam brancas, pois o JPEG não tem canal alfa.
+func Resize(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			width, height = maxSide, max(1, height*maxSide/width)
+		} else {
+			width, height = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// IsSize informa se o nome é um dos tamanhos gerados
+func IsSize(name string) bool {
+	_, ok := Sizes[strings.ToLower(name)]
+	return ok
+}
+
+// decodeImage confere a resolução antes de decodificar a imagem
+func decodeImage(reader io.ReadSeeker) (image.Image, error) {
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return nil, fmt.Errorf("imagem inválida: %w", err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("imagem inválida: %w", err)
+	}
+
+	return img, nil
+}
@@ -23,8 +23,8 @@ func (q *Queries) CountAttachments(ctx context.Context) (int64, error) {
 }
 
 const createAttachment = `-- name: CreateAttachment :one
-INSERT INTO attachments (filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at
+INSERT INTO attachments (filename, filepath, filesize, filetype, checksum, status, user_id, attachable_type, attachable_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at
 `
 
 type CreateAttachmentParams struct {
@@ -32,6 +32,8 @@ type CreateAttachmentParams struct {
 	Filepath       string      `json:"filepath"`
 	Filesize       int64       `json:"filesize"`
 	Filetype       string      `json:"filetype"`
+	Checksum       pgtype.Text `json:"checksum"`
+	Status         string      `json:"status"`
 	UserID         pgtype.Int8 `json:"user_id"`
 	AttachableType string      `json:"attachable_type"`
 	AttachableID   int64       `json:"attachable_id"`
@@ -43,6 +45,8 @@ func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentPara
 		arg.Filepath,
 		arg.Filesize,
 		arg.Filetype,
+		arg.Checksum,
+		arg.Status,
 		arg.UserID,
 		arg.AttachableType,
 		arg.AttachableID,
@@ -59,6 +63,11 @@ func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentPara
 		&i.AttachableID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Checksum,
+		&i.Status,
+		&i.ScanResult,
+		&i.ScannedAt,
+		&i.ThumbnailGeneratedAt,
 	)
 	return i, err
 }
@@ -73,8 +82,75 @@ func (q *Queries) DeleteAttachment(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteAttachmentsByAttachable = `-- name: DeleteAttachmentsByAttachable :many
+DELETE FROM attachments
+WHERE attachable_type = $1 AND attachable_id = ANY($2::bigint[])
+RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at
+`
+
+type DeleteAttachmentsByAttachableParams struct {
+	AttachableType string  `json:"attachable_type"`
+	AttachableIds  []int64 `json:"attachable_ids"`
+}
+
+func (q *Queries) DeleteAttachmentsByAttachable(ctx context.Context, arg DeleteAttachmentsByAttachableParams) ([]Attachment, error) {
+	rows, err := q.db.Query(ctx, deleteAttachmentsByAttachable, arg.AttachableType, arg.AttachableIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Filename,
+			&i.Filepath,
+			&i.Filesize,
+			&i.Filetype,
+			&i.UserID,
+			&i.AttachableType,
+			&i.AttachableID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Checksum,
+			&i.Status,
+			&i.ScanResult,
+			&i.ScannedAt,
+			&i.ThumbnailGeneratedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAttachableProjectId = `-- name: FindAttachableProjectId :one
+SELECT (CASE $1::text
+    WHEN 'project' THEN $2::bigint
+    WHEN 'task' THEN (SELECT t.project_id FROM tasks t WHERE t.id = $2::bigint)
+    WHEN 'subtask' THEN (SELECT t.project_id FROM subtasks s JOIN tasks t ON t.id = s.task_id WHERE s.id = $2::bigint)
+END)::bigint AS project_id
+`
+
+type FindAttachableProjectIdParams struct {
+	AttachableType string `json:"attachable_type"`
+	AttachableID   int64  `json:"attachable_id"`
+}
+
+func (q *Queries) FindAttachableProjectId(ctx context.Context, arg FindAttachableProjectIdParams) (pgtype.Int8, error) {
+	row := q.db.QueryRow(ctx, findAttachableProjectId, arg.AttachableType, arg.AttachableID)
+	var project_id pgtype.Int8
+	err := row.Scan(&project_id)
+	return project_id, err
+}
+
 const findAttachmentById = `-- name: FindAttachmentById :one
-SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at FROM attachments WHERE id = $1
+SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at FROM attachments WHERE id = $1
 `
 
 func (q *Queries) FindAttachmentById(ctx context.Context, id int64) (Attachment, error) {
@@ -91,12 +167,17 @@ func (q *Queries) FindAttachmentById(ctx context.Context, id int64) (Attachment,
 		&i.AttachableID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Checksum,
+		&i.Status,
+		&i.ScanResult,
+		&i.ScannedAt,
+		&i.ThumbnailGeneratedAt,
 	)
 	return i, err
 }
 
 const findAttachmentsByAttachable = `-- name: FindAttachmentsByAttachable :many
-SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at FROM attachments WHERE attachable_type = $1 AND attachable_id = $2
+SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at FROM attachments WHERE attachable_type = $1 AND attachable_id = $2
 `
 
 type FindAttachmentsByAttachableParams struct {
@@ -124,6 +205,11 @@ func (q *Queries) FindAttachmentsByAttachable(ctx context.Context, arg FindAttac
 			&i.AttachableID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Checksum,
+			&i.Status,
+			&i.ScanResult,
+			&i.ScannedAt,
+			&i.ThumbnailGeneratedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -136,7 +222,7 @@ func (q *Queries) FindAttachmentsByAttachable(ctx context.Context, arg FindAttac
 }
 
 const findAttachmentsByUserId = `-- name: FindAttachmentsByUserId :many
-SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at FROM attachments WHERE user_id = $1
+SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at FROM attachments WHERE user_id = $1
 `
 
 func (q *Queries) FindAttachmentsByUserId(ctx context.Context, userID pgtype.Int8) ([]Attachment, error) {
@@ -159,6 +245,11 @@ func (q *Queries) FindAttachmentsByUserId(ctx context.Context, userID pgtype.Int
 			&i.AttachableID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Checksum,
+			&i.Status,
+			&i.ScanResult,
+			&i.ScannedAt,
+			&i.ThumbnailGeneratedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -171,7 +262,7 @@ func (q *Queries) FindAttachmentsByUserId(ctx context.Context, userID pgtype.Int
 }
 
 const findManyAttachments = `-- name: FindManyAttachments :many
-SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at FROM attachments
+SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at FROM attachments
 `
 
 func (q *Queries) FindManyAttachments(ctx context.Context) ([]Attachment, error) {
@@ -194,6 +285,11 @@ func (q *Queries) FindManyAttachments(ctx context.Context) ([]Attachment, error)
 			&i.AttachableID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Checksum,
+			&i.Status,
+			&i.ScanResult,
+			&i.ScannedAt,
+			&i.ThumbnailGeneratedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -206,7 +302,7 @@ func (q *Queries) FindManyAttachments(ctx context.Context) ([]Attachment, error)
 }
 
 const findManyAttachmentsWithPagination = `-- name: FindManyAttachmentsWithPagination :many
-SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at FROM attachments
+SELECT id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at FROM attachments
 WHERE id > 0
 ORDER BY id
 LIMIT $2 OFFSET $1
@@ -237,6 +333,11 @@ func (q *Queries) FindManyAttachmentsWithPagination(ctx context.Context, arg Fin
 			&i.AttachableID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Checksum,
+			&i.Status,
+			&i.ScanResult,
+			&i.ScannedAt,
+			&i.ThumbnailGeneratedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -248,11 +349,50 @@ func (q *Queries) FindManyAttachmentsWithPagination(ctx context.Context, arg Fin
 	return items, nil
 }
 
+const lockStorageQuota = `-- name: LockStorageQuota :exec
+SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))
+`
+
+func (q *Queries) LockStorageQuota(ctx context.Context, scope string) error {
+	_, err := q.db.Exec(ctx, lockStorageQuota, scope)
+	return err
+}
+
+const sumAttachmentSizeByProject = `-- name: SumAttachmentSizeByProject :one
+SELECT COALESCE(SUM(a.filesize), 0)::bigint AS total
+FROM attachments a
+WHERE (a.attachable_type = 'project' AND a.attachable_id = $1::bigint)
+   OR (a.attachable_type = 'task' AND a.attachable_id IN (SELECT t.id FROM tasks t WHERE t.project_id = $1::bigint))
+   OR (a.attachable_type = 'subtask' AND a.attachable_id IN (
+        SELECT s.id FROM subtasks s JOIN tasks t ON t.id = s.task_id WHERE t.project_id = $1::bigint
+   ))
+`
+
+func (q *Queries) SumAttachmentSizeByProject(ctx context.Context, projectID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, sumAttachmentSizeByProject, projectID)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const sumAttachmentSizeByUser = `-- name: SumAttachmentSizeByUser :one
+SELECT COALESCE(SUM(filesize), 0)::bigint AS total
+FROM attachments
+WHERE user_id = $1
+`
+
+func (q *Queries) SumAttachmentSizeByUser(ctx context.Context, userID pgtype.Int8) (int64, error) {
+	row := q.db.QueryRow(ctx, sumAttachmentSizeByUser, userID)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const updateAttachment = `-- name: UpdateAttachment :one
 UPDATE attachments
 SET filename = $1, filepath = $2, filesize = $3, filetype = $4, updated_at = CURRENT_TIMESTAMP
 WHERE id = $5
-RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at
+RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at
 `
 
 type UpdateAttachmentParams struct {
@@ -283,6 +423,64 @@ func (q *Queries) UpdateAttachment(ctx context.Context, arg UpdateAttachmentPara
 		&i.AttachableID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Checksum,
+		&i.Status,
+		&i.ScanResult,
+		&i.ScannedAt,
+		&i.ThumbnailGeneratedAt,
 	)
 	return i, err
 }
+
+const updateAttachmentScanResult = `-- name: UpdateAttachmentScanResult :one
+UPDATE attachments
+SET status = $1, filepath = $2, scan_result = $3, scanned_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $4
+RETURNING id, filename, filepath, filesize, filetype, user_id, attachable_type, attachable_id, created_at, updated_at, checksum, status, scan_result, scanned_at, thumbnail_generated_at
+`
+
+type UpdateAttachmentScanResultParams struct {
+	Status     string      `json:"status"`
+	Filepath   string      `json:"filepath"`
+	ScanResult pgtype.Text `json:"scan_result"`
+	ID         int64       `json:"id"`
+}
+
+func (q *Queries) UpdateAttachmentScanResult(ctx context.Context, arg UpdateAttachmentScanResultParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, updateAttachmentScanResult,
+		arg.Status,
+		arg.Filepath,
+		arg.ScanResult,
+		arg.ID,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.Filepath,
+		&i.Filesize,
+		&i.Filetype,
+		&i.UserID,
+		&i.AttachableType,
+		&i.AttachableID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Checksum,
+		&i.Status,
+		&i.ScanResult,
+		&i.ScannedAt,
+		&i.ThumbnailGeneratedAt,
+	)
+	return i, err
+}
+
+const updateAttachmentThumbnail = `-- name: UpdateAttachmentThumbnail :exec
+UPDATE attachments
+SET thumbnail_generated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) UpdateAttachmentThumbnail(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, updateAttachmentThumbnail, id)
+	return err
+}
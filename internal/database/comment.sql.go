@@ -64,6 +64,21 @@ func (q *Queries) DeleteComment(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteCommentsByCommentable = `-- name: DeleteCommentsByCommentable :exec
+DELETE FROM comments
+WHERE commentable_type = $1 AND commentable_id = ANY($2::bigint[])
+`
+
+type DeleteCommentsByCommentableParams struct {
+	CommentableType string  `json:"commentable_type"`
+	CommentableIds  []int64 `json:"commentable_ids"`
+}
+
+func (q *Queries) DeleteCommentsByCommentable(ctx context.Context, arg DeleteCommentsByCommentableParams) error {
+	_, err := q.db.Exec(ctx, deleteCommentsByCommentable, arg.CommentableType, arg.CommentableIds)
+	return err
+}
+
 const findCommentById = `-- name: FindCommentById :one
 SELECT id, content, user_id, commentable_type, commentable_id, created_at, updated_at FROM comments WHERE id = $1
 `
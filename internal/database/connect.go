@@ -2,65 +2,108 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
-	"github.com/jackc/pgx/v5"
-	_ "github.com/jackc/pgx/v5/stdlib" // Driver pgx para database/sql
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib" // Driver pgx para database/sql
 	"github.com/jmoiron/sqlx"
+
+	"sixTask/config/appConfig"
 )
 
+// ErrUnavailable indica que não foi possível obter uma conexão com o banco de dados
+var ErrUnavailable = errors.New("banco de dados indisponível")
+
+// pool é o pool de conexões compartilhado por todo o processo
+var pool *pgxpool.Pool
+
 // SQLXAdapter é um adaptador que permite usar sqlx.DB com a interface DBTX
 type SQLXAdapter struct {
 	DB *sqlx.DB
 }
 
-// getConnectionString retorna a string de conexão para o banco de dados PostgreSQL
-func getConnectionString() string {
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USERNAME")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_DATABASE")
-
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
-}
+// NewPool cria o pool de conexões com o banco de dados a partir da configuração carregada.
+// O pool não é encerrado se o banco estiver fora do ar: as conexões são abertas sob demanda.
+func NewPool(ctx context.Context, cfg appConfig.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("configuração do banco de dados inválida: %w", err)
+	}
 
-// ConnectDB conecta ao banco de dados usando pgx e retorna uma conexão pgx
-func ConnectDB() (*pgx.Conn, context.Context) {
-	connString := getConnectionString()
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns >= 0 && cfg.MinConns <= poolConfig.MaxConns {
+		poolConfig.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+	if cfg.ConnectTimeout > 0 {
+		poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	}
+	poolConfig.ConnConfig.Tracer = queryTracer{slow: cfg.SlowQueryThreshold}
 
-	conn, err := pgx.Connect(context.Background(), connString)
+	p, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		log.Fatalf("Unable to connect to database: %v", err)
+		return nil, fmt.Errorf("erro ao criar pool de conexões: %w", err)
 	}
 
-	err = conn.Ping(context.Background())
-	if err != nil {
-		log.Fatalf("Unable to ping database: %v", err)
+	// Apenas registra a falha: o servidor continua no ar e responde 503 até o banco voltar
+	if err := p.Ping(ctx); err != nil {
+		slog.Warn("Banco de dados indisponível na inicialização", "error", err)
+	} else {
+		slog.Info("Conectado ao banco de dados", "host", cfg.Host, "database", cfg.Database)
+	}
+
+	return p, nil
+}
+
+// SetPool define o pool compartilhado usado pelos handlers e repositórios
+func SetPool(p *pgxpool.Pool) {
+	pool = p
+}
+
+// GetPool retorna o pool compartilhado ou ErrUnavailable se ele não foi inicializado
+func GetPool() (*pgxpool.Pool, error) {
+	if pool == nil {
+		return nil, ErrUnavailable
 	}
 
-	log.Println("Successfully connected to database using pgx")
-	ctx := context.Background()
-	return conn, ctx
+	return pool, nil
 }
 
-// ConnectDBX conecta ao banco de dados usando sqlx e retorna um DB sqlx
-func ConnectDBX() *sqlx.DB {
-	connString := getConnectionString()
+// AcquireConn obtém uma conexão do pool compartilhado.
+// A conexão deve ser devolvida com Release; falhas são retornadas como ErrUnavailable.
+func AcquireConn(ctx context.Context) (*pgxpool.Conn, error) {
+	p, err := GetPool()
+	if err != nil {
+		return nil, err
+	}
 
-	// Usando o driver pgx com sqlx
-	db, err := sqlx.Connect("pgx", connString)
+	conn, err := p.Acquire(ctx)
 	if err != nil {
-		log.Fatalf("Unable to connect to database with sqlx: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
 	}
 
-	err = db.Ping()
+	return conn, nil
+}
+
+// ConnectDBX retorna um sqlx.DB apoiado no pool compartilhado.
+// Fechar o sqlx.DB apenas devolve as conexões ao pool.
+func ConnectDBX() (*sqlx.DB, error) {
+	p, err := GetPool()
 	if err != nil {
-		log.Fatalf("Unable to ping database with sqlx: %v", err)
+		return nil, err
 	}
 
-	log.Println("Successfully connected to database using sqlx")
-	return db
+	return sqlx.NewDb(stdlib.OpenDBFromPool(p), "pgx"), nil
 }
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sixTask/config/metrics"
+)
+
+// RegisterMetrics expõe em /metrics as estatísticas do pool compartilhado, lidas a cada coleta
+func RegisterMetrics() {
+	metrics.Register(poolCollector{})
+}
+
+var (
+	poolMaxConns             = prometheus.NewDesc("db_pool_max_conns", "Tamanho máximo do pool de conexões.", nil, nil)
+	poolTotalConns           = prometheus.NewDesc("db_pool_total_conns", "Conexões abertas no pool.", nil, nil)
+	poolAcquiredConns        = prometheus.NewDesc("db_pool_acquired_conns", "Conexões do pool em uso.", nil, nil)
+	poolIdleConns            = prometheus.NewDesc("db_pool_idle_conns", "Conexões ociosas no pool.", nil, nil)
+	poolConstructingConns    = prometheus.NewDesc("db_pool_constructing_conns", "Conexões sendo abertas.", nil, nil)
+	poolAcquireTotal         = prometheus.NewDesc("db_pool_acquire_total", "Conexões obtidas do pool.", nil, nil)
+	poolEmptyAcquireTotal    = prometheus.NewDesc("db_pool_empty_acquire_total", "Conexões obtidas depois de esperar por uma conexão livre.", nil, nil)
+	poolCanceledAcquireTotal = prometheus.NewDesc("db_pool_canceled_acquire_total", "Esperas por conexão canceladas pelo contexto.", nil, nil)
+	poolAcquireDuration      = prometheus.NewDesc("db_pool_acquire_duration_seconds_total", "Tempo total de espera para obter conexões.", nil, nil)
+)
+
+// poolCollector lê as estatísticas do pool a cada coleta, como o collectors.NewDBStatsCollector faz para o database/sql
+type poolCollector struct{}
+
+func (poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		poolMaxConns, poolTotalConns, poolAcquiredConns, poolIdleConns, poolConstructingConns,
+		poolAcquireTotal, poolEmptyAcquireTotal, poolCanceledAcquireTotal, poolAcquireDuration,
+	} {
+		ch <- desc
+	}
+}
+
+func (poolCollector) Collect(ch chan<- prometheus.Metric) {
+	p, err := GetPool()
+	if err != nil {
+		return
+	}
+
+	stat := p.Stat()
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolConstructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireTotal, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquireTotal, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquireTotal, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
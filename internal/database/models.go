@@ -9,16 +9,21 @@ import (
 )
 
 type Attachment struct {
-	ID             int64            `json:"id"`
-	Filename       string           `json:"filename"`
-	Filepath       string           `json:"filepath"`
-	Filesize       int64            `json:"filesize"`
-	Filetype       string           `json:"filetype"`
-	UserID         pgtype.Int8      `json:"user_id"`
-	AttachableType string           `json:"attachable_type"`
-	AttachableID   int64            `json:"attachable_id"`
-	CreatedAt      pgtype.Timestamp `json:"created_at"`
-	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	ID                   int64            `json:"id"`
+	Filename             string           `json:"filename"`
+	Filepath             string           `json:"filepath"`
+	Filesize             int64            `json:"filesize"`
+	Filetype             string           `json:"filetype"`
+	UserID               pgtype.Int8      `json:"user_id"`
+	AttachableType       string           `json:"attachable_type"`
+	AttachableID         int64            `json:"attachable_id"`
+	CreatedAt            pgtype.Timestamp `json:"created_at"`
+	UpdatedAt            pgtype.Timestamp `json:"updated_at"`
+	Checksum             pgtype.Text      `json:"checksum"`
+	Status               string           `json:"status"`
+	ScanResult           pgtype.Text      `json:"scan_result"`
+	ScannedAt            pgtype.Timestamp `json:"scanned_at"`
+	ThumbnailGeneratedAt pgtype.Timestamp `json:"thumbnail_generated_at"`
 }
 
 type Client struct {
@@ -41,6 +46,41 @@ type Comment struct {
 	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
 }
 
+type Export struct {
+	ID           int64            `json:"id"`
+	UserID       int64            `json:"user_id"`
+	Resource     string           `json:"resource"`
+	Format       string           `json:"format"`
+	Filters      []byte           `json:"filters"`
+	Status       string           `json:"status"`
+	Filename     pgtype.Text      `json:"filename"`
+	Filepath     pgtype.Text      `json:"filepath"`
+	RowCount     int32            `json:"row_count"`
+	ErrorMessage pgtype.Text      `json:"error_message"`
+	FinishedAt   pgtype.Timestamp `json:"finished_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+type Import struct {
+	ID           int64            `json:"id"`
+	UserID       pgtype.Int8      `json:"user_id"`
+	TemplateID   pgtype.Int8      `json:"template_id"`
+	Filename     string           `json:"filename"`
+	Filepath     string           `json:"filepath"`
+	Status       string           `json:"status"`
+	TotalRows    int32            `json:"total_rows"`
+	InsertedRows int32            `json:"inserted_rows"`
+	UpdatedRows  int32            `json:"updated_rows"`
+	FailedRows   int32            `json:"failed_rows"`
+	ReportPath   pgtype.Text      `json:"report_path"`
+	ErrorMessage pgtype.Text      `json:"error_message"`
+	StartedAt    pgtype.Timestamp `json:"started_at"`
+	FinishedAt   pgtype.Timestamp `json:"finished_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
 type Notification struct {
 	ID             int64            `json:"id"`
 	UserID         pgtype.Int8      `json:"user_id"`
@@ -72,6 +112,17 @@ type ProjectUser struct {
 	ProjectID pgtype.Int8 `json:"project_id"`
 }
 
+type RefreshToken struct {
+	ID         int64            `json:"id"`
+	UserID     int64            `json:"user_id"`
+	TokenHash  string           `json:"token_hash"`
+	FamilyID   string           `json:"family_id"`
+	ExpiresAt  pgtype.Timestamp `json:"expires_at"`
+	RevokedAt  pgtype.Timestamp `json:"revoked_at"`
+	ReplacedBy pgtype.Int8      `json:"replaced_by"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
 type Shipment struct {
 	ShipmentID                            pgtype.Text    `json:"shipment_id"`
 	Trans                                 pgtype.Text    `json:"trans"`
@@ -215,6 +266,11 @@ type Task struct {
 	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
 }
 
+type TaskUser struct {
+	UserID pgtype.Int8 `json:"user_id"`
+	TaskID pgtype.Int8 `json:"task_id"`
+}
+
 type Template struct {
 	ID                  int64            `json:"id"`
 	Titulo              string           `json:"titulo"`
@@ -225,9 +281,37 @@ type Template struct {
 	UpdatedAt           pgtype.Timestamp `json:"updated_at"`
 }
 
+type UploadSession struct {
+	ID             string           `json:"id"`
+	CreatedBy      int64            `json:"created_by"`
+	UserID         pgtype.Int8      `json:"user_id"`
+	AttachableType string           `json:"attachable_type"`
+	AttachableID   int64            `json:"attachable_id"`
+	Filename       string           `json:"filename"`
+	UploadLength   int64            `json:"upload_length"`
+	UploadOffset   int64            `json:"upload_offset"`
+	ChunkKeys      []string         `json:"chunk_keys"`
+	ExpiresAt      pgtype.Timestamp `json:"expires_at"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	Status         string           `json:"status"`
+}
+
 type User struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	ID              int64            `json:"id"`
+	Name            string           `json:"name"`
+	Email           string           `json:"email"`
+	Password        string           `json:"password"`
+	Role            string           `json:"role"`
+	EmailVerifiedAt pgtype.Timestamp `json:"email_verified_at"`
+}
+
+type UserToken struct {
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"user_id"`
+	Type      string           `json:"type"`
+	TokenHash string           `json:"token_hash"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
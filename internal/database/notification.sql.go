@@ -72,6 +72,21 @@ func (q *Queries) DeleteNotification(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteNotificationsByNotifiable = `-- name: DeleteNotificationsByNotifiable :exec
+DELETE FROM notifications
+WHERE notifiable_type = $1 AND notifiable_id = ANY($2::bigint[])
+`
+
+type DeleteNotificationsByNotifiableParams struct {
+	NotifiableType string  `json:"notifiable_type"`
+	NotifiableIds  []int64 `json:"notifiable_ids"`
+}
+
+func (q *Queries) DeleteNotificationsByNotifiable(ctx context.Context, arg DeleteNotificationsByNotifiableParams) error {
+	_, err := q.db.Exec(ctx, deleteNotificationsByNotifiable, arg.NotifiableType, arg.NotifiableIds)
+	return err
+}
+
 const findManyNotifications = `-- name: FindManyNotifications :many
 SELECT id, user_id, title, content, type, read, notifiable_type, notifiable_id, created_at, updated_at, read_at FROM notifications
 `
@@ -612,6 +612,30 @@ func (q *Queries) FindProjectById(ctx context.Context, id int64) (Project, error
 	return i, err
 }
 
+const findProjectIdsByClientId = `-- name: FindProjectIdsByClientId :many
+SELECT id FROM projects WHERE client_id = $1
+`
+
+func (q *Queries) FindProjectIdsByClientId(ctx context.Context, clientID pgtype.Int8) ([]int64, error) {
+	rows, err := q.db.Query(ctx, findProjectIdsByClientId, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findProjectWithUsers = `-- name: FindProjectWithUsers :one
 SELECT
     p.id,
@@ -782,6 +806,25 @@ func (q *Queries) FindProjectsByUser(ctx context.Context, userID pgtype.Int8) ([
 	return items, nil
 }
 
+const isProjectMember = `-- name: IsProjectMember :one
+SELECT EXISTS (
+    SELECT 1 FROM project_user
+    WHERE project_id = $1 AND user_id = $2
+)
+`
+
+type IsProjectMemberParams struct {
+	ProjectID pgtype.Int8 `json:"project_id"`
+	UserID    pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) IsProjectMember(ctx context.Context, arg IsProjectMemberParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isProjectMember, arg.ProjectID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const updateProject = `-- name: UpdateProject :one
 UPDATE projects
 SET name        = $1,
@@ -196,6 +196,30 @@ func (q *Queries) FindSubtaskById(ctx context.Context, id int64) (Subtask, error
 	return i, err
 }
 
+const findSubtaskIdsByTaskIds = `-- name: FindSubtaskIdsByTaskIds :many
+SELECT id FROM subtasks WHERE task_id = ANY($1::bigint[])
+`
+
+func (q *Queries) FindSubtaskIdsByTaskIds(ctx context.Context, taskIds []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, findSubtaskIdsByTaskIds, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findSubtasksByAssignedTo = `-- name: FindSubtasksByAssignedTo :many
 SELECT id, title, description, task_id, assigned_to, status, due_date, completed_at, created_at, updated_at FROM subtasks WHERE assigned_to = $1
 `
@@ -301,6 +325,32 @@ func (q *Queries) FindSubtasksByTaskId(ctx context.Context, taskID pgtype.Int8)
 	return items, nil
 }
 
+const isSubtaskMember = `-- name: IsSubtaskMember :one
+SELECT EXISTS (
+    SELECT 1 FROM subtasks s
+    JOIN tasks t ON t.id = s.task_id
+    WHERE s.id = $1
+      AND (
+        s.assigned_to = $2
+        OR t.assigned_to = $2
+        OR EXISTS (SELECT 1 FROM task_user tu WHERE tu.task_id = t.id AND tu.user_id = $2)
+        OR EXISTS (SELECT 1 FROM project_user pu WHERE pu.project_id = t.project_id AND pu.user_id = $2)
+      )
+)
+`
+
+type IsSubtaskMemberParams struct {
+	SubtaskID int64       `json:"subtask_id"`
+	UserID    pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) IsSubtaskMember(ctx context.Context, arg IsSubtaskMemberParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isSubtaskMember, arg.SubtaskID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const updateSubtask = `-- name: UpdateSubtask :one
 UPDATE subtasks
 SET title = $1, description = $2, task_id = $3, assigned_to = $4,
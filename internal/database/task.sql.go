@@ -90,6 +90,21 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 	return i, err
 }
 
+const createUserTask = `-- name: CreateUserTask :exec
+insert into task_user (user_id, task_id)
+values ($1, $2)
+`
+
+type CreateUserTaskParams struct {
+	UserID pgtype.Int8 `json:"user_id"`
+	TaskID pgtype.Int8 `json:"task_id"`
+}
+
+func (q *Queries) CreateUserTask(ctx context.Context, arg CreateUserTaskParams) error {
+	_, err := q.db.Exec(ctx, createUserTask, arg.UserID, arg.TaskID)
+	return err
+}
+
 const deleteTask = `-- name: DeleteTask :exec
 DELETE FROM tasks
 WHERE id = $1
@@ -100,6 +115,16 @@ func (q *Queries) DeleteTask(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteUserTasksByTaskIds = `-- name: DeleteUserTasksByTaskIds :exec
+DELETE FROM task_user
+WHERE task_id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteUserTasksByTaskIds(ctx context.Context, taskIds []int64) error {
+	_, err := q.db.Exec(ctx, deleteUserTasksByTaskIds, taskIds)
+	return err
+}
+
 const findManyTasks = `-- name: FindManyTasks :many
 SELECT id, title, description, project_id, assigned_to, status, priority, due_date, completed_at, created_at, updated_at FROM tasks
 `
@@ -203,6 +228,30 @@ func (q *Queries) FindTaskById(ctx context.Context, id int64) (Task, error) {
 	return i, err
 }
 
+const findTaskIdsByProjectIds = `-- name: FindTaskIdsByProjectIds :many
+SELECT id FROM tasks WHERE project_id = ANY($1::bigint[])
+`
+
+func (q *Queries) FindTaskIdsByProjectIds(ctx context.Context, projectIds []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, findTaskIdsByProjectIds, projectIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findTasksByAssignedTo = `-- name: FindTasksByAssignedTo :many
 SELECT id, title, description, project_id, assigned_to, status, priority, due_date, completed_at, created_at, updated_at FROM tasks WHERE assigned_to = $1
 `
@@ -392,7 +441,7 @@ func (q *Queries) FindTasksWithUsersPaginated(ctx context.Context, arg FindTasks
 }
 
 const findUsersByTaskIds = `-- name: FindUsersByTaskIds :many
-SELECT u.id, u.name, u.email, u.password FROM users u
+SELECT u.id, u.name, u.email, u.password, u.role, u.email_verified_at FROM users u
 JOIN tasks t ON t.assigned_to = u.id
 WHERE t.id IN ($1)
 ORDER BY u.id
@@ -412,6 +461,8 @@ func (q *Queries) FindUsersByTaskIds(ctx context.Context, taskIds []int64) ([]Us
 			&i.Name,
 			&i.Email,
 			&i.Password,
+			&i.Role,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -423,6 +474,30 @@ func (q *Queries) FindUsersByTaskIds(ctx context.Context, taskIds []int64) ([]Us
 	return items, nil
 }
 
+const isTaskMember = `-- name: IsTaskMember :one
+SELECT EXISTS (
+    SELECT 1 FROM tasks t
+    WHERE t.id = $1
+      AND (
+        t.assigned_to = $2
+        OR EXISTS (SELECT 1 FROM task_user tu WHERE tu.task_id = t.id AND tu.user_id = $2)
+        OR EXISTS (SELECT 1 FROM project_user pu WHERE pu.project_id = t.project_id AND pu.user_id = $2)
+      )
+)
+`
+
+type IsTaskMemberParams struct {
+	TaskID int64       `json:"task_id"`
+	UserID pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) IsTaskMember(ctx context.Context, arg IsTaskMemberParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isTaskMember, arg.TaskID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const updateTask = `-- name: UpdateTask :one
 UPDATE tasks
 SET title = $1, description = $2, project_id = $3, assigned_to = $4,
@@ -10,7 +10,7 @@ import (
 )
 
 const findAll = `-- name: FindAll :many
-SELECT id, name, email, password FROM users
+SELECT id, name, email, password, role, email_verified_at FROM users
 `
 
 func (q *Queries) FindAll(ctx context.Context) ([]User, error) {
@@ -27,6 +27,8 @@ func (q *Queries) FindAll(ctx context.Context) ([]User, error) {
 			&i.Name,
 			&i.Email,
 			&i.Password,
+			&i.Role,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -10,7 +10,7 @@ import (
 )
 
 const findById2 = `-- name: FindById2 :one
-select id, name, email, password from users where id = $1
+select id, name, email, password, role, email_verified_at from users where id = $1
 `
 
 func (q *Queries) FindById2(ctx context.Context, id int64) (User, error) {
@@ -21,6 +21,8 @@ func (q *Queries) FindById2(ctx context.Context, id int64) (User, error) {
 		&i.Name,
 		&i.Email,
 		&i.Password,
+		&i.Role,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
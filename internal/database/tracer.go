@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"sixTask/config/tracing"
+)
+
+// queryTracer registra as consultas no slog com o contexto de quem as executou, de modo que
+// os registros dos repositórios trazem o request_id da requisição (ou do job) de origem.
+// Todas as consultas aparecem no nível debug; as lentas e as que falharam, como aviso.
+// Cada consulta também vira um span, filho do span da requisição ou do job.
+type queryTracer struct {
+	slow time.Duration
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name, operation := queryName(data.SQL)
+	ctx, _ = tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(compactSQL(data.SQL)),
+		),
+	)
+
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, start: time.Now()})
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	if errors.Is(data.Err, pgx.ErrNoRows) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, data.Err)
+	}
+
+	duration := time.Since(start.start)
+	attrs := []any{
+		"sql", compactSQL(start.sql),
+		"duration_ms", duration.Milliseconds(),
+		"rows", data.CommandTag.RowsAffected(),
+	}
+
+	switch {
+	case data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) && ctx.Err() == nil:
+		slog.WarnContext(ctx, "Erro na consulta ao banco", append(attrs, "error", data.Err)...)
+	case t.slow > 0 && duration >= t.slow:
+		slog.WarnContext(ctx, "Consulta lenta", attrs...)
+	default:
+		slog.DebugContext(ctx, "Consulta ao banco", attrs...)
+	}
+}
+
+// compactSQL remove as quebras de linha e a indentação, deixando a consulta em uma linha
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// queryName retorna o nome do span e a operação da consulta: o nome dado pelo sqlc
+// (-- name: GetTask :one) ou, nas consultas escritas à mão, o primeiro comando (SELECT, UPDATE...)
+func queryName(sql string) (name, operation string) {
+	fields := strings.Fields(sql)
+	if len(fields) >= 3 && fields[0] == "--" && fields[1] == "name:" {
+		name, fields = fields[2], fields[3:]
+		for len(fields) > 0 && strings.HasPrefix(fields[0], ":") {
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	if name == "" {
+		name = operation
+	}
+
+	return name, operation
+}
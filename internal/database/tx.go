@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RunInTx executa fn dentro de uma transação do pool compartilhado.
+// Faz commit se fn retornar nil e rollback em caso de erro ou panic.
+func RunInTx(ctx context.Context, fn func(queries *Queries) error) error {
+	p, err := GetPool()
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	// Rollback após o Commit não tem efeito
+	defer tx.Rollback(context.Background())
+
+	if err := fn(New(p).WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RunInPgxTx executa fn dentro de uma transação do pool compartilhado, expondo a pgx.Tx
+// para operações que o sqlc não gera, como CopyFrom e SQL com tabelas dinâmicas.
+// Faz commit se fn retornar nil e rollback em caso de erro ou panic.
+func RunInPgxTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	p, err := GetPool()
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	// Rollback após o Commit não tem efeito
+	defer tx.Rollback(context.Background())
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -22,42 +22,72 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 }
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, email, password)
-VALUES ($1, $2, $3)
-RETURNING id, name, email, password
+INSERT INTO users (name, email, password, role)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, email, password, role, email_verified_at
 `
 
 type CreateUserParams struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Role     string `json:"role"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Email, arg.Password)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Name,
+		arg.Email,
+		arg.Password,
+		arg.Role,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Email,
 		&i.Password,
+		&i.Role,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
+const deleteUser = `-- name: DeleteUser :execrows
 DELETE
 FROM users
 WHERE id = $1
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
-	return err
+func (q *Queries) DeleteUser(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const existsUserByEmail = `-- name: ExistsUserByEmail :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE email = $1 AND id <> $2
+)
+`
+
+type ExistsUserByEmailParams struct {
+	Email    string `json:"email"`
+	IgnoreID int64  `json:"ignore_id"`
+}
+
+func (q *Queries) ExistsUserByEmail(ctx context.Context, arg ExistsUserByEmailParams) (bool, error) {
+	row := q.db.QueryRow(ctx, existsUserByEmail, arg.Email, arg.IgnoreID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
 }
 
 const findByEmail = `-- name: FindByEmail :one
-SELECT id, name, email, password
+SELECT id, name, email, password, role, email_verified_at
 FROM users
 WHERE email = $1
 LIMIT 1
@@ -71,12 +101,14 @@ func (q *Queries) FindByEmail(ctx context.Context, email string) (User, error) {
 		&i.Name,
 		&i.Email,
 		&i.Password,
+		&i.Role,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const findById = `-- name: FindById :one
-SELECT id, name, email, password
+SELECT id, name, email, password, role, email_verified_at
 FROM users
 WHERE id = $1
 `
@@ -89,12 +121,14 @@ func (q *Queries) FindById(ctx context.Context, id int64) (User, error) {
 		&i.Name,
 		&i.Email,
 		&i.Password,
+		&i.Role,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const findMany = `-- name: FindMany :many
-SELECT id, name, email, password
+SELECT id, name, email, password, role, email_verified_at
 FROM users
 `
 
@@ -112,6 +146,8 @@ func (q *Queries) FindMany(ctx context.Context) ([]User, error) {
 			&i.Name,
 			&i.Email,
 			&i.Password,
+			&i.Role,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -124,7 +160,7 @@ func (q *Queries) FindMany(ctx context.Context) ([]User, error) {
 }
 
 const findManyUserIds = `-- name: FindManyUserIds :many
-SELECT id, name, email, password
+SELECT id, name, email, password, role, email_verified_at
 FROM users
 WHERE id = ANY($1::bigint[])
 `
@@ -143,6 +179,8 @@ func (q *Queries) FindManyUserIds(ctx context.Context, ids []int64) ([]User, err
 			&i.Name,
 			&i.Email,
 			&i.Password,
+			&i.Role,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -155,7 +193,7 @@ func (q *Queries) FindManyUserIds(ctx context.Context, ids []int64) ([]User, err
 }
 
 const findManyWithPagination = `-- name: FindManyWithPagination :many
-SELECT id, name, email, password
+SELECT id, name, email, password, role, email_verified_at
 FROM users
 WHERE id > 0
 ORDER BY id
@@ -181,6 +219,8 @@ func (q *Queries) FindManyWithPagination(ctx context.Context, arg FindManyWithPa
 			&i.Name,
 			&i.Email,
 			&i.Password,
+			&i.Role,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -192,19 +232,32 @@ func (q *Queries) FindManyWithPagination(ctx context.Context, arg FindManyWithPa
 	return items, nil
 }
 
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+`
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markUserEmailVerified, id)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name     = $1,
     email    = $2,
-    password = $3
-WHERE id = $4
-RETURNING id, name, email, password
+    password = $3,
+    role     = $4
+WHERE id = $5
+RETURNING id, name, email, password, role, email_verified_at
 `
 
 type UpdateUserParams struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Role     string `json:"role"`
 	ID       int64  `json:"id"`
 }
 
@@ -213,6 +266,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.Name,
 		arg.Email,
 		arg.Password,
+		arg.Role,
 		arg.ID,
 	)
 	var i User
@@ -221,6 +275,24 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Name,
 		&i.Email,
 		&i.Password,
+		&i.Role,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $1
+WHERE id = $2
+`
+
+type UpdateUserPasswordParams struct {
+	Password string `json:"password"`
+	ID       int64  `json:"id"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.Password, arg.ID)
+	return err
+}
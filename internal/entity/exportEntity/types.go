@@ -0,0 +1,71 @@
+package exportEntity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/exportStatusTypes"
+	"sixTask/internal/types/exportTypes"
+)
+
+// Export representa uma exportação sem expor o caminho do arquivo no storage
+type Export struct {
+	ID           int64               `json:"id"`
+	UserID       int64               `json:"user_id"`
+	Resource     string              `json:"resource"`
+	Format       string              `json:"format"`
+	Filters      exportTypes.Filters `json:"filters"`
+	Status       string              `json:"status"`
+	Filename     *string             `json:"filename"`
+	RowCount     int32               `json:"row_count"`
+	ErrorMessage *string             `json:"error_message"`
+	// DownloadURL é preenchido quando a exportação está concluída
+	DownloadURL string     `json:"download_url,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// DownloadPath é a rota autenticada de download do arquivo da exportação
+func DownloadPath(id int64) string {
+	return fmt.Sprintf("/api/exports/%d/download", id)
+}
+
+// FromDatabaseExport converte um database.Export para exportEntity.Export
+func FromDatabaseExport(dbExport database.Export) Export {
+	export := Export{
+		ID:        dbExport.ID,
+		UserID:    dbExport.UserID,
+		Resource:  dbExport.Resource,
+		Format:    dbExport.Format,
+		Status:    dbExport.Status,
+		RowCount:  dbExport.RowCount,
+		CreatedAt: dbExport.CreatedAt.Time,
+	}
+	json.Unmarshal(dbExport.Filters, &export.Filters)
+	if dbExport.Filename.Valid {
+		export.Filename = &dbExport.Filename.String
+	}
+	if dbExport.ErrorMessage.Valid {
+		export.ErrorMessage = &dbExport.ErrorMessage.String
+	}
+	if dbExport.FinishedAt.Valid {
+		export.FinishedAt = &dbExport.FinishedAt.Time
+	}
+	if dbExport.Status == exportStatusTypes.Completed {
+		export.DownloadURL = DownloadPath(dbExport.ID)
+	}
+
+	return export
+}
+
+// FromDatabaseExports converte uma lista de database.Export
+func FromDatabaseExports(dbExports []database.Export) []Export {
+	exports := make([]Export, 0, len(dbExports))
+	for _, dbExport := range dbExports {
+		exports = append(exports, FromDatabaseExport(dbExport))
+	}
+
+	return exports
+}
@@ -9,18 +9,19 @@ import (
 
 // Task representa uma tarefa com informações do usuário associado
 type Task struct {
-	ID          int64            `json:"id"`
-	Title       string           `json:"title"`
-	Description pgtype.Text      `json:"description"`
-	ProjectID   pgtype.Int8      `json:"project_id"`
-	AssignedTo  pgtype.Int8      `json:"assigned_to"`
-	Status      string           `json:"status"`
-	Priority    string           `json:"priority"`
-	DueDate     pgtype.Date      `json:"due_date"`
-	CompletedAt pgtype.Timestamp `json:"completed_at"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	User        *userEntity.User `json:"user,omitempty"`
+	ID          int64             `json:"id"`
+	Title       string            `json:"title"`
+	Description pgtype.Text       `json:"description"`
+	ProjectID   pgtype.Int8       `json:"project_id"`
+	AssignedTo  pgtype.Int8       `json:"assigned_to"`
+	Status      string            `json:"status"`
+	Priority    string            `json:"priority"`
+	DueDate     pgtype.Date       `json:"due_date"`
+	CompletedAt pgtype.Timestamp  `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp  `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp  `json:"updated_at"`
+	User        *userEntity.User  `json:"user,omitempty"`
+	Users       []userEntity.User `json:"users,omitempty"`
 }
 
 // TaskWithPagination contém as tarefas paginadas com informações de usuário e metadados de paginação
@@ -54,6 +55,18 @@ func FromDatabaseTask(dbTask database.Task) Task {
 	}
 }
 
+// GetTaskEntity monta a tarefa com os usuários relacionados pela tabela task_user
+func GetTaskEntity(task database.Task, users []database.User) Task {
+	result := FromDatabaseTask(task)
+
+	result.Users = make([]userEntity.User, 0, len(users))
+	for _, user := range users {
+		result.Users = append(result.Users, userEntity.FromDatabaseUser(user))
+	}
+
+	return result
+}
+
 // ParseTasksWithUsers combina tarefas e usuários em uma única estrutura
 func ParseTasksWithUsers(tasks []database.Task, users []database.User) []Task {
 	// Criar um mapa de usuários por ID para facilitar a busca
@@ -0,0 +1,49 @@
+package templateEntity
+
+import (
+	"encoding/json"
+	"time"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/templateTypes"
+)
+
+// Template representa um template de importação com as colunas já decodificadas
+type Template struct {
+	ID                  int64                   `json:"id"`
+	Titulo              string                  `json:"titulo"`
+	Tabela              string                  `json:"tabela"`
+	ColunaIdentificacao string                  `json:"coluna_identificacao"`
+	Colunas             []templateTypes.Colunas `json:"colunas"`
+	CreatedAt           time.Time               `json:"created_at"`
+	UpdatedAt           *time.Time              `json:"updated_at"`
+}
+
+// FromDatabaseTemplate converte um database.Template para templateEntity.Template.
+// Colunas mal formatadas no banco resultam em uma lista vazia.
+func FromDatabaseTemplate(dbTemplate database.Template) Template {
+	template := Template{
+		ID:                  dbTemplate.ID,
+		Titulo:              dbTemplate.Titulo,
+		Tabela:              dbTemplate.Tabela,
+		ColunaIdentificacao: dbTemplate.ColunaIdentificacao,
+		Colunas:             []templateTypes.Colunas{},
+		CreatedAt:           dbTemplate.CreatedAt.Time,
+	}
+	json.Unmarshal(dbTemplate.Colunas, &template.Colunas)
+	if dbTemplate.UpdatedAt.Valid {
+		template.UpdatedAt = &dbTemplate.UpdatedAt.Time
+	}
+
+	return template
+}
+
+// FromDatabaseTemplates converte uma lista de database.Template
+func FromDatabaseTemplates(dbTemplates []database.Template) []Template {
+	templates := make([]Template, 0, len(dbTemplates))
+	for _, dbTemplate := range dbTemplates {
+		templates = append(templates, FromDatabaseTemplate(dbTemplate))
+	}
+
+	return templates
+}
@@ -1,19 +1,42 @@
 package userEntity
 
-import "sixTask/internal/database"
+import (
+	"time"
+
+	"sixTask/internal/database"
+)
 
 // User representa um usuário no sistema
 type User struct {
 	ID    int64  `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
+	// EmailVerifiedAt é nulo enquanto o email não for confirmado
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 }
 
 // FromDatabaseUser converte um database.User para userEntity.User
 func FromDatabaseUser(dbUser database.User) User {
-	return User{
+	user := User{
 		ID:    dbUser.ID,
 		Name:  dbUser.Name,
 		Email: dbUser.Email,
+		Role:  dbUser.Role,
+	}
+	if dbUser.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &dbUser.EmailVerifiedAt.Time
 	}
+
+	return user
+}
+
+// FromDatabaseUsers converte uma lista de database.User sem expor o hash da senha
+func FromDatabaseUsers(dbUsers []database.User) []User {
+	users := make([]User, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		users = append(users, FromDatabaseUser(dbUser))
+	}
+
+	return users
 }
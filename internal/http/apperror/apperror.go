@@ -0,0 +1,141 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Códigos dos erros devolvidos pela API, estáveis para que os clientes possam tratá-los
+const (
+	CodeBadRequest     = "bad_request"
+	CodeValidation     = "validation_failed"
+	CodeUnauthorized   = "unauthorized"
+	CodeForbidden      = "forbidden"
+	CodeNotFound       = "not_found"
+	CodeConflict       = "conflict"
+	CodeUnprocessable  = "unprocessable"
+	CodeTooLarge       = "payload_too_large"
+	CodeUnsupported    = "unsupported_media_type"
+	CodeLengthRequired = "length_required"
+	CodeLocked         = "locked"
+	CodeUnavailable    = "unavailable"
+	CodeInternal       = "internal_error"
+)
+
+// AppError é um erro da API com o status HTTP, o código e a mensagem exibida ao cliente.
+// A causa original fica em Err: aparece nos logs e no trace, mas nunca na resposta.
+type AppError struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  map[string]string
+	Err     error
+}
+
+// Problem é o corpo JSON de todas as respostas de erro da API. A chave "error" traz a mensagem,
+// como nas respostas anteriores a este formato.
+type Problem struct {
+	Error     string            `json:"error"`
+	Code      string            `json:"code"`
+	Status    int               `json:"status"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// New cria um erro com status, código e mensagem
+func New(status int, code, message string) *AppError {
+	return &AppError{Status: status, Code: code, Message: message}
+}
+
+// BadRequest indica uma requisição malformada (ID inválido, parâmetro ausente, corpo ilegível)
+func BadRequest(message string) *AppError {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// Unauthorized indica que a requisição não está autenticada
+func Unauthorized(message string) *AppError {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// Forbidden indica que o usuário autenticado não pode executar a ação
+func Forbidden(message string) *AppError {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+// NotFound indica que o registro não existe
+func NotFound(message string) *AppError {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Conflict indica que a ação conflita com o estado atual, como um registro duplicado
+func Conflict(message string) *AppError {
+	return New(http.StatusConflict, CodeConflict, message)
+}
+
+// Unprocessable indica dados bem formados mas que não podem ser aplicados, como uma referência inexistente
+func Unprocessable(message string) *AppError {
+	return New(http.StatusUnprocessableEntity, CodeUnprocessable, message)
+}
+
+// TooLarge indica que o corpo ou o arquivo enviado excede o tamanho permitido
+func TooLarge(message string) *AppError {
+	return New(http.StatusRequestEntityTooLarge, CodeTooLarge, message)
+}
+
+// UnsupportedMediaType indica que o formato do conteúdo enviado não é aceito
+func UnsupportedMediaType(message string) *AppError {
+	return New(http.StatusUnsupportedMediaType, CodeUnsupported, message)
+}
+
+// Unavailable indica que uma dependência (banco, Redis, storage) está fora
+func Unavailable(message string) *AppError {
+	return New(http.StatusServiceUnavailable, CodeUnavailable, message)
+}
+
+// Internal indica uma falha inesperada; err é registrado no log e message é o que o cliente recebe
+func Internal(message string, err error) *AppError {
+	return &AppError{Status: http.StatusInternalServerError, Code: CodeInternal, Message: message, Err: err}
+}
+
+// Error inclui a causa, para os logs; a resposta usa apenas Message
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+
+	return e.Message
+}
+
+// Unwrap permite usar errors.Is e errors.As com a causa
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// WithCause retorna uma cópia do erro com a causa informada
+func (e *AppError) WithCause(err error) *AppError {
+	clone := *e
+	clone.Err = err
+
+	return &clone
+}
+
+// WithFields retorna uma cópia do erro com as mensagens por campo
+func (e *AppError) WithFields(fields map[string]string) *AppError {
+	clone := *e
+	clone.Fields = fields
+
+	return &clone
+}
+
+// Problem monta o corpo da resposta, sem a causa
+func (e *AppError) Problem(requestID string) Problem {
+	return Problem{Error: e.Message, Code: e.Code, Status: e.Status, Fields: e.Fields, RequestID: requestID}
+}
+
+// As retorna o AppError contido em err, se houver
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	ok := errors.As(err, &appErr)
+
+	return appErr, ok
+}
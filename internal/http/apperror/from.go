@@ -0,0 +1,67 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"sixTask/internal/database"
+	"sixTask/internal/http/validator"
+)
+
+// Wrap converte err no AppError correspondente, mantendo-o como causa. Erros que já são AppError
+// são devolvidos como estão; registros inexistentes viram 404, violações de unicidade 409, de
+// chave estrangeira e das demais restrições 422 e a indisponibilidade do banco 503. Os demais
+// viram 500 com message, que descreve a operação (ex.: "Erro ao buscar tarefas").
+func Wrap(err error, message string) *AppError {
+	if appErr, ok := As(err); ok {
+		return appErr
+	}
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return NotFound("Registro não encontrado").WithCause(err)
+	case errors.Is(err, database.ErrUnavailable):
+		return Unavailable("Banco de dados indisponível").WithCause(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505":
+			return Conflict("Já existe um registro com estes dados").WithCause(err)
+		case pgErr.Code == "23503":
+			return Unprocessable("Registro relacionado não encontrado ou ainda em uso").WithCause(err)
+		// Classes 22 (dados inválidos) e 23 (demais restrições de integridade)
+		case strings.HasPrefix(pgErr.Code, "22"), strings.HasPrefix(pgErr.Code, "23"):
+			return Unprocessable("Dados inválidos").WithCause(err)
+		}
+	}
+
+	return Internal(message, err)
+}
+
+// From converte err em AppError; falhas desconhecidas recebem a mensagem genérica de erro interno
+func From(err error) *AppError {
+	return Wrap(err, "Erro interno do servidor")
+}
+
+// Validation converte um erro do ShouldBind* em AppError. Regras de validação violadas viram 422,
+// com a mensagem de cada campo traduzida pelo validator; corpos ilegíveis viram 400.
+func Validation(err error) *AppError {
+	if fields := validator.Fields(err); fields != nil {
+		return New(http.StatusUnprocessableEntity, CodeValidation, "Dados inválidos: "+validator.Translate(err)).
+			WithCause(err).
+			WithFields(fields)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return TooLarge("Corpo da requisição excede o tamanho máximo permitido").WithCause(err)
+	}
+
+	return BadRequest("Dados inválidos: " + validator.Translate(err)).WithCause(err)
+}
@@ -0,0 +1,106 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"sixTask/internal/database"
+	"sixTask/internal/http/validator"
+)
+
+func TestWrap(t *testing.T) {
+	forbidden := Forbidden("Sem permissão")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"AppError é mantido", forbidden, http.StatusForbidden, CodeForbidden},
+		{"AppError embrulhado é mantido", fmt.Errorf("policy: %w", forbidden), http.StatusForbidden, CodeForbidden},
+		{"registro inexistente", pgx.ErrNoRows, http.StatusNotFound, CodeNotFound},
+		{"registro inexistente embrulhado", fmt.Errorf("buscar tarefa: %w", pgx.ErrNoRows), http.StatusNotFound, CodeNotFound},
+		{"banco indisponível", fmt.Errorf("pool: %w", database.ErrUnavailable), http.StatusServiceUnavailable, CodeUnavailable},
+		{"unicidade", &pgconn.PgError{Code: "23505"}, http.StatusConflict, CodeConflict},
+		{"unicidade embrulhada", fmt.Errorf("inserir: %w", &pgconn.PgError{Code: "23505"}), http.StatusConflict, CodeConflict},
+		{"chave estrangeira", &pgconn.PgError{Code: "23503"}, http.StatusUnprocessableEntity, CodeUnprocessable},
+		{"not null", &pgconn.PgError{Code: "23502"}, http.StatusUnprocessableEntity, CodeUnprocessable},
+		{"check", &pgconn.PgError{Code: "23514"}, http.StatusUnprocessableEntity, CodeUnprocessable},
+		{"texto longo demais", &pgconn.PgError{Code: "22001"}, http.StatusUnprocessableEntity, CodeUnprocessable},
+		{"sintaxe de valor inválida", &pgconn.PgError{Code: "22P02"}, http.StatusUnprocessableEntity, CodeUnprocessable},
+		{"outro erro do postgres", &pgconn.PgError{Code: "42P01"}, http.StatusInternalServerError, CodeInternal},
+		{"erro qualquer", errors.New("falha"), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.err, "Erro ao buscar tarefas")
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode {
+				t.Fatalf("Wrap() = %d %s, esperado %d %s", got.Status, got.Code, tt.wantStatus, tt.wantCode)
+			}
+
+			var appErr *AppError
+			if !errors.As(tt.err, &appErr) && !errors.Is(got.Err, tt.err) {
+				t.Errorf("Wrap() deveria manter %v como causa, causa = %v", tt.err, got.Err)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsAppErrorInstance(t *testing.T) {
+	forbidden := Forbidden("Sem permissão")
+
+	if got := Wrap(fmt.Errorf("policy: %w", forbidden), "Erro"); got != forbidden {
+		t.Errorf("Wrap() = %+v, esperado o próprio AppError", got)
+	}
+}
+
+func TestWrapMessage(t *testing.T) {
+	if got := Wrap(errors.New("falha"), "Erro ao buscar tarefas"); got.Message != "Erro ao buscar tarefas" {
+		t.Errorf("Message = %q, esperado a mensagem da operação", got.Message)
+	}
+	if got := From(errors.New("falha")); got.Message != "Erro interno do servidor" {
+		t.Errorf("From().Message = %q, esperado a mensagem genérica", got.Message)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	validator.InitValidator()
+
+	var request struct {
+		Name string `json:"name" binding:"required"`
+	}
+	bindErr := binding.Validator.ValidateStruct(&request)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantFields bool
+	}{
+		{"regra do binding", bindErr, http.StatusUnprocessableEntity, CodeValidation, true},
+		{"corpo acima do limite", &http.MaxBytesError{Limit: 1024}, http.StatusRequestEntityTooLarge, CodeTooLarge, false},
+		{"json malformado", json.Unmarshal([]byte("{"), &request), http.StatusBadRequest, CodeBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validation(tt.err)
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode {
+				t.Fatalf("Validation() = %d %s, esperado %d %s", got.Status, got.Code, tt.wantStatus, tt.wantCode)
+			}
+			if _, ok := got.Fields["name"]; ok != tt.wantFields {
+				t.Errorf("Fields = %v", got.Fields)
+			}
+		})
+	}
+}
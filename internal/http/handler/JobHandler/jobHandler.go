@@ -1,36 +1,54 @@
 package JobHandler
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
-	"sixTask/config/queue"
+
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/http/request/RequestModel"
+	"sixTask/internal/jobs"
+	"sixTask/internal/policy"
+	"sixTask/internal/service/jobService"
 )
 
+// DisparJob enfileira o job de exemplo e devolve o ID para acompanhamento em /api/jobs/:id
 func DisparJob(c *gin.Context) {
-
 	var request RequestModel.Pessoa
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
 
-	err := c.ShouldBindJSON(&request)
+	info, err := jobs.EnqueueJobModel(c.Request.Context(), request, policy.GetActor(c).UserID)
 	if err != nil {
-		c.JSON(500, err.Error())
+		c.Error(apperror.Unavailable("Fila de jobs indisponível").WithCause(err))
 		return
 	}
 
-	//task, err := jobs.NewJobModel(request)
-	queueCliente := queue.Conect()
-
-	defer queueCliente.Close()
+	c.JSON(http.StatusAccepted, gin.H{
+		"job_id":     info.ID,
+		"status_url": "/api/jobs/" + info.ID,
+	})
+}
 
+// GetJob retorna a situação, o andamento e o resultado de um job
+func GetJob(c *gin.Context) {
+	status, err := jobService.GetStatus(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, jobService.ErrJobNotFound) {
+		c.Error(apperror.NotFound("Job não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(400, gin.H{
-			"meessage": err.Error(),
-		})
+		c.Error(apperror.Unavailable("Fila de jobs indisponível").WithCause(err))
+		return
 	}
 
-	//queueCliente.Enqueue(task, asynq.Queue("default"))
-
-	c.JSON(200, gin.H{
-		"message": "processamento concluido",
-	})
+	if err := policy.CanAccessJob(policy.GetActor(c), status.RequestedBy); err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.JSON(http.StatusOK, status)
 }
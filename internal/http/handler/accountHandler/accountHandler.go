@@ -0,0 +1,104 @@
+package accountHandler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/accountRequest"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/userRepository"
+	"sixTask/internal/repository/userTokenRepository"
+	"sixTask/internal/service/accountService"
+)
+
+// ForgotPassword envia o link de redefinição de senha.
+// A resposta é a mesma para emails cadastrados ou não.
+func ForgotPassword(c *gin.Context) {
+	var request accountRequest.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	err := accountService.RequestPasswordReset(c.Request.Context(), request.Email, emailprovider.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao solicitar redefinição de senha"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Se o email estiver cadastrado, você receberá um link para redefinir a senha"})
+}
+
+// ResetPassword troca a senha usando o token recebido por email
+func ResetPassword(c *gin.Context) {
+	var request accountRequest.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	err := accountService.ResetPassword(c.Request.Context(), request.Token, request.Password)
+	if errors.Is(err, userTokenRepository.ErrInvalidToken) {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao redefinir senha"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso"})
+}
+
+// VerifyEmail confirma o email usando o token recebido por email
+func VerifyEmail(c *gin.Context) {
+	var request accountRequest.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	err := accountService.VerifyEmail(c.Request.Context(), request.Token)
+	if errors.Is(err, userTokenRepository.ErrInvalidToken) {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao confirmar email"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email confirmado com sucesso"})
+}
+
+// ResendVerification reenvia o link de confirmação para o usuário autenticado
+func ResendVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := userRepository.GetUser(ctx, policy.GetActor(c).UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Usuário não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar usuário"))
+		return
+	}
+
+	err = accountService.SendEmailVerification(ctx, user, emailprovider.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")))
+	if errors.Is(err, accountService.ErrAlreadyVerified) {
+		c.Error(apperror.Unprocessable("Email já confirmado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao enviar confirmação de email"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Link de confirmação enviado"})
+}
@@ -1,26 +1,48 @@
 package attachmentHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"sixTask/config/storageProvider"
+	"sixTask/helpers/thumbnail"
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/handler/fileHandler"
 	"sixTask/internal/http/request/attachmentRequest"
+	uploadmiddleware "sixTask/internal/middleware/uploadMiddleware"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/attachmentRepository"
+	"sixTask/internal/service/attachmentService"
 )
 
 // GetAttachments retorna todos os anexos
 func GetAttachments(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	attachments, err := queries.FindManyAttachments(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar anexos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexos"))
 		return
 	}
 
@@ -29,19 +51,34 @@ func GetAttachments(c *gin.Context) {
 
 // GetAttachment retorna um anexo pelo ID
 func GetAttachment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
 		return
 	}
 
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	attachment, err := queries.FindAttachmentById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Anexo não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Anexo não encontrado"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexo"))
 		return
 	}
 
@@ -50,22 +87,33 @@ func GetAttachment(c *gin.Context) {
 
 // GetAttachmentsByUser retorna anexos pelo ID do usuário
 func GetAttachmentsByUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
 		return
 	}
 
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
 
 	queries := database.New(conn)
 	attachments, err := queries.FindAttachmentsByUserId(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar anexos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexos"))
 		return
 	}
 
@@ -74,20 +122,31 @@ func GetAttachmentsByUser(c *gin.Context) {
 
 // GetAttachmentsByAttachable retorna anexos pelo tipo e ID do objeto anexável
 func GetAttachmentsByAttachable(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	attachableType := c.Param("attachable_type")
 	if attachableType == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo de objeto anexável inválido"})
+		c.Error(apperror.BadRequest("Tipo de objeto anexável inválido"))
 		return
 	}
 
 	attachableId, err := strconv.ParseInt(c.Param("attachable_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objeto anexável inválido"})
+		c.Error(apperror.BadRequest("ID do objeto anexável inválido"))
+		return
+	}
+
+	if err := policy.CanAccessMorph(ctx, policy.GetActor(c), attachableType, attachableId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	params := database.FindAttachmentsByAttachableParams{
 		AttachableType: attachableType,
@@ -97,84 +156,265 @@ func GetAttachmentsByAttachable(c *gin.Context) {
 	queries := database.New(conn)
 	attachments, err := queries.FindAttachmentsByAttachable(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar anexos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexos"))
 		return
 	}
 
 	c.JSON(http.StatusOK, attachments)
 }
 
-// CreateAttachment cria um novo anexo
+// CreateAttachment recebe o upload multipart de um arquivo e cria o anexo
 func CreateAttachment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	var request attachmentRequest.CreateAttachmentRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+	if err := c.ShouldBind(&request); err != nil {
+		if uploadmiddleware.IsBodyTooLarge(err) {
+			c.Error(apperror.TooLarge("Arquivo excede o tamanho máximo permitido"))
+			return
+		}
+		c.Error(apperror.Validation(err))
 		return
 	}
 
-	// Converte a request para o formato esperado pelo sqlc
-	params := request.ToCreateAttachmentParams().(database.CreateAttachmentParams)
+	actor := policy.GetActor(c)
+	ownerID := request.OwnerID(actor.UserID)
+	if err := policy.CanCreateAttachment(ctx, actor, ownerID, request.AttachableType, request.AttachableID); err != nil {
+		c.Error(err)
+		return
+	}
 
-	queries := database.New(conn)
-	attachment, err := queries.CreateAttachment(ctx, params)
+	attachment, err := attachmentService.Upload(ctx, request, ownerID)
+	if errors.Is(err, storageProvider.ErrFileTooLarge) || errors.Is(err, attachmentService.ErrQuotaExceeded) {
+		c.Error(apperror.TooLarge(err.Error()))
+		return
+	}
+	if errors.Is(err, storageProvider.ErrTypeNotAllowed) {
+		c.Error(apperror.UnsupportedMediaType(err.Error()))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar anexo: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar anexo"))
 		return
 	}
 
 	c.JSON(http.StatusCreated, attachment)
 }
 
-// UpdateAttachment atualiza um anexo existente
+// UpdateAttachment renomeia um anexo existente
 func UpdateAttachment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanModifyAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
 		return
 	}
 
 	var request attachmentRequest.UpdateAttachmentRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	current, err := attachmentRepository.GetAttachment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Anexo não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexo"))
 		return
 	}
 
 	// Converte a request para o formato esperado pelo sqlc
-	params := request.ToUpdateAttachmentParams(id).(database.UpdateAttachmentParams)
+	params := request.ToUpdateAttachmentParams(current).(database.UpdateAttachmentParams)
 
-	queries := database.New(conn)
-	attachment, err := queries.UpdateAttachment(ctx, params)
+	attachment, err := attachmentRepository.UpdateAttachment(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar anexo: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar anexo"))
 		return
 	}
 
 	c.JSON(http.StatusOK, attachment)
 }
 
-// DeleteAttachment remove um anexo
+// DeleteAttachment remove um anexo e o arquivo armazenado
 func DeleteAttachment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	err = queries.DeleteAttachment(ctx, id)
+	if err := policy.CanModifyAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err = attachmentService.Delete(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Anexo não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover anexo: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover anexo"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Anexo removido com sucesso"})
 }
+
+// DownloadAttachment envia o arquivo do anexo a quem pode acessar o registro ao qual ele pertence
+func DownloadAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	serveAttachment(c, id)
+}
+
+// GetAttachmentSignedURL gera um link temporário para baixar o anexo sem autenticação.
+// A validade vem de ?ttl= (ex.: 1h), limitada a 7 dias; o padrão é ATTACHMENT_URL_TTL.
+func GetAttachmentSignedURL(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ttl := attachmentService.DefaultURLTTL()
+	if value := c.Query("ttl"); value != "" {
+		ttl, err = time.ParseDuration(value)
+		if err != nil || ttl <= 0 || ttl > attachmentService.MaxURLTTL {
+			c.Error(apperror.BadRequest("ttl inválido; use uma duração entre 1s e 168h"))
+			return
+		}
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	c.JSON(http.StatusOK, gin.H{
+		"url":        attachmentService.SignedURL(id, expiresAt),
+		"expires_at": expiresAt.UTC(),
+	})
+}
+
+// DownloadSignedAttachment envia o arquivo do anexo a partir de um link assinado
+func DownloadSignedAttachment(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := attachmentService.VerifySignedURL(id, c.Request.URL.Query()); err != nil {
+		c.Error(apperror.Forbidden(err.Error()))
+		return
+	}
+
+	serveAttachment(c, id)
+}
+
+// GetAttachmentThumbnail envia a miniatura JPEG do anexo (?size=small ou medium, padrão small).
+// Miniaturas existem apenas para imagens e PDFs e são geradas pelo worker após o upload.
+func GetAttachmentThumbnail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	size := strings.ToLower(c.DefaultQuery("size", thumbnail.DefaultSize))
+	if !thumbnail.IsSize(size) {
+		c.Error(apperror.BadRequest("size inválido; use small ou medium"))
+		return
+	}
+
+	if err := policy.CanAccessAttachment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachment, err := attachmentRepository.GetAttachment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Anexo não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexo"))
+		return
+	}
+
+	if !respondDownloadable(c, attachment) {
+		return
+	}
+
+	key, err := attachmentService.ThumbnailFor(attachment, size)
+	if err != nil {
+		c.Error(apperror.NotFound("Miniatura não disponível"))
+		return
+	}
+
+	name := strings.TrimSuffix(attachment.Filename, filepath.Ext(attachment.Filename)) + ".jpg"
+	fileHandler.ServeFile(c, key, name, thumbnail.ContentType, attachment.Checksum.String+"-"+size)
+}
+
+// serveAttachment busca o anexo e envia o arquivo com o nome original.
+// Anexos pendentes de verificação ou em quarentena não são entregues.
+func serveAttachment(c *gin.Context, id int64) {
+	attachment, err := attachmentRepository.GetAttachment(c.Request.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Anexo não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar anexo"))
+		return
+	}
+
+	if !respondDownloadable(c, attachment) {
+		return
+	}
+
+	fileHandler.ServeFile(c, attachment.Filepath, attachment.Filename, attachment.Filetype, attachment.Checksum.String)
+}
+
+// respondDownloadable responde com o erro adequado quando o arquivo do anexo não pode ser entregue
+func respondDownloadable(c *gin.Context, attachment database.Attachment) bool {
+	err := attachmentService.CheckDownloadable(attachment)
+	if errors.Is(err, attachmentService.ErrScanPending) {
+		c.Error(apperror.Conflict(err.Error()))
+		return false
+	}
+	if err != nil {
+		c.Error(apperror.New(http.StatusLocked, apperror.CodeLocked, err.Error()))
+		return false
+	}
+
+	return true
+}
@@ -1,16 +1,24 @@
 package authhandler
 
 import (
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 
 	authhelper "sixTask/helpers/authHelper"
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/userRequest"
+	"sixTask/internal/repository/refreshTokenRepository"
 )
 
+// refreshTokenInput representa o corpo das rotas de refresh e logout
+type refreshTokenInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 // make the Login with Password and email
 func Login(c *gin.Context) {
 
@@ -20,49 +28,85 @@ func Login(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
-	dbConn, ctx := database.ConnectDB()
-	defer dbConn.Close(ctx)
+	ctx := c.Request.Context()
+	dbConn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer dbConn.Release()
 
 	query := database.New(dbConn)
 
-	user, err := query.FindByEmail(ctx, input.Email)
+	user, err := query.FindByEmail(ctx, userRequest.NormalizeEmail(input.Email))
 
+	// Email inexistente recebe a mesma resposta da senha incorreta
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.BadRequest("usuario e ou senha incorretos"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar usuário"))
 		return
 	}
 
 	passwordCheck := authhelper.CheckPasswordHash(input.Password, user.Password)
 
 	if !passwordCheck {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "usuario e ou senha incorretos"})
+		c.Error(apperror.BadRequest("usuario e ou senha incorretos"))
 		return
 	}
 
-	claims := &authhelper.Claims{
-		UserID: user.ID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	// Inicia uma nova sessão com o primeiro refresh token
+	session, err := refreshTokenRepository.CreateSession(ctx, user.ID)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao criar sessão"))
+		return
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	respondWithTokens(c, session)
+}
+
+// Refresh troca um refresh token válido por um novo par de tokens (rotação)
+func Refresh(c *gin.Context) {
+	var input refreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
 
-	secretKey := authhelper.GetSecret()
-	tokenString, err := token.SignedString(secretKey)
+	session, err := refreshTokenRepository.Rotate(c.Request.Context(), input.RefreshToken)
+	if errors.Is(err, refreshTokenRepository.ErrInvalidRefreshToken) || errors.Is(err, refreshTokenRepository.ErrRefreshTokenReused) {
+		c.Error(apperror.Unauthorized(err.Error()))
+		return
+	}
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao renovar token"))
 		return
 	}
 
-	c.JSON(200, gin.H{
-		"token": tokenString,
-	})
+	respondWithTokens(c, session)
+}
+
+// Logout revoga a sessão do refresh token informado, invalidando também os access tokens emitidos para ela
+func Logout(c *gin.Context) {
+	var input refreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	err := refreshTokenRepository.RevokeSession(c.Request.Context(), input.RefreshToken)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao encerrar sessão"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logout realizado com sucesso"})
 }
 
 func Profile(c *gin.Context) {
@@ -73,3 +117,19 @@ func Profile(c *gin.Context) {
 		"user": user,
 	})
 }
+
+// respondWithTokens gera o access token da sessão e responde com o par de tokens
+func respondWithTokens(c *gin.Context, session refreshTokenRepository.Session) {
+	tokenString, err := authhelper.GenerateAccessToken(session.UserID, session.SessionID)
+	if err != nil {
+		c.Error(apperror.Internal("Erro ao gerar token", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokenString,
+		"refresh_token": session.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(authhelper.GetAccessTokenTTL().Seconds()),
+	})
+}
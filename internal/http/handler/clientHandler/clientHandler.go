@@ -1,22 +1,30 @@
 package clientHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/http/request/clientRequest"
-	"sixTask/internal/http/validator"
+	"sixTask/internal/policy"
 	"sixTask/internal/repository/clientRepository"
+	"sixTask/internal/service/attachmentService"
 )
 
 // GetClients retorna todos os clientes com paginação
 func GetClients(c *gin.Context) {
-	ctx := context.Background()
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	// Parâmetros de paginação
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -25,7 +33,7 @@ func GetClients(c *gin.Context) {
 	// Buscar clientes com paginação via repositório
 	result, err := clientRepository.GetClientsWithPagination(ctx, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar clientes: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar clientes"))
 		return
 	}
 
@@ -35,17 +43,26 @@ func GetClients(c *gin.Context) {
 
 // GetClient retorna um cliente pelo ID
 func GetClient(c *gin.Context) {
-	ctx := context.Background()
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
 	client, err := clientRepository.GetClient(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Cliente não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Cliente não encontrado"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar cliente"))
 		return
 	}
 
@@ -54,11 +71,16 @@ func GetClient(c *gin.Context) {
 
 // CreateClient cria um novo cliente
 func CreateClient(c *gin.Context) {
-	ctx := context.Background()
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	var request clientRequest.CreateClientRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
@@ -78,7 +100,7 @@ func CreateClient(c *gin.Context) {
 		Address: params.Address,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar cliente: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar cliente"))
 		return
 	}
 
@@ -87,17 +109,22 @@ func CreateClient(c *gin.Context) {
 
 // UpdateClient atualiza um cliente existente
 func UpdateClient(c *gin.Context) {
-	ctx := context.Background()
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
 	var request clientRequest.UpdateClientRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
@@ -119,7 +146,7 @@ func UpdateClient(c *gin.Context) {
 		ID:      params.ID,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar cliente: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar cliente"))
 		return
 	}
 
@@ -128,19 +155,27 @@ func UpdateClient(c *gin.Context) {
 
 // DeleteClient remove um cliente
 func DeleteClient(c *gin.Context) {
-	ctx := context.Background()
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	err = clientRepository.DeleteClient(ctx, id)
+	attachments, err := clientRepository.DeleteClient(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover cliente: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover cliente"))
 		return
 	}
 
+	// Os arquivos dos anexos removidos só são apagados do storage depois do commit
+	attachmentService.RemoveFiles(ctx, attachments)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Cliente removido com sucesso"})
 }
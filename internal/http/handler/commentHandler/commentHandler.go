@@ -1,26 +1,39 @@
 package commentHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/http/request/commentRequest"
+	"sixTask/internal/policy"
 )
 
 // GetComments retorna todos os comentários
 func GetComments(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	comments, err := queries.FindManyComments(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comentários: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar comentários"))
 		return
 	}
 
@@ -29,19 +42,34 @@ func GetComments(c *gin.Context) {
 
 // GetComment retorna um comentário pelo ID
 func GetComment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessComment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	comment, err := queries.FindCommentById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Comentário não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Comentário não encontrado"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar comentário"))
 		return
 	}
 
@@ -50,14 +78,25 @@ func GetComment(c *gin.Context) {
 
 // GetCommentsByUser retorna comentários pelo ID do usuário
 func GetCommentsByUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
+
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
@@ -65,7 +104,7 @@ func GetCommentsByUser(c *gin.Context) {
 	queries := database.New(conn)
 	comments, err := queries.FindCommentsByUserId(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comentários: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar comentários"))
 		return
 	}
 
@@ -74,20 +113,31 @@ func GetCommentsByUser(c *gin.Context) {
 
 // GetCommentsByCommentable retorna comentários pelo tipo e ID do objeto comentável
 func GetCommentsByCommentable(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	commentableType := c.Param("commentable_type")
 	if commentableType == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo de objeto comentável inválido"})
+		c.Error(apperror.BadRequest("Tipo de objeto comentável inválido"))
 		return
 	}
 
 	commentableId, err := strconv.ParseInt(c.Param("commentable_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objeto comentável inválido"})
+		c.Error(apperror.BadRequest("ID do objeto comentável inválido"))
+		return
+	}
+
+	if err := policy.CanAccessMorph(ctx, policy.GetActor(c), commentableType, commentableId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	params := database.FindCommentsByCommentableParams{
 		CommentableType: commentableType,
@@ -97,7 +147,7 @@ func GetCommentsByCommentable(c *gin.Context) {
 	queries := database.New(conn)
 	comments, err := queries.FindCommentsByCommentable(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comentários: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar comentários"))
 		return
 	}
 
@@ -106,22 +156,33 @@ func GetCommentsByCommentable(c *gin.Context) {
 
 // CreateComment cria um novo comentário
 func CreateComment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	var request commentRequest.CreateCommentRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := policy.CanCreateComment(ctx, policy.GetActor(c), request.UserID, request.CommentableType, request.CommentableID); err != nil {
+		c.Error(err)
 		return
 	}
 
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converte a request para o formato esperado pelo sqlc
 	params := request.ToCreateCommentParams().(database.CreateCommentParams)
 
 	queries := database.New(conn)
 	comment, err := queries.CreateComment(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar comentário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar comentário"))
 		return
 	}
 
@@ -130,18 +191,29 @@ func CreateComment(c *gin.Context) {
 
 // UpdateComment atualiza um comentário existente
 func UpdateComment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
+	if err := policy.CanModifyComment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	var request commentRequest.UpdateCommentRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
@@ -151,7 +223,7 @@ func UpdateComment(c *gin.Context) {
 	queries := database.New(conn)
 	comment, err := queries.UpdateComment(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar comentário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar comentário"))
 		return
 	}
 
@@ -160,19 +232,30 @@ func UpdateComment(c *gin.Context) {
 
 // DeleteComment remove um comentário
 func DeleteComment(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanModifyComment(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	err = queries.DeleteComment(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover comentário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover comentário"))
 		return
 	}
 
@@ -0,0 +1,172 @@
+package exportHandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/database"
+	"sixTask/internal/entity/exportEntity"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/handler/fileHandler"
+	"sixTask/internal/http/request/exportRequest"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/exportRepository"
+	"sixTask/internal/service/exportService"
+)
+
+// ExportTasks solicita a exportação das tarefas com os mesmos filtros e permissões da listagem:
+// por projeto para membros do projeto, por responsável para o próprio usuário e, sem esses
+// filtros, apenas para gerentes
+func ExportTasks(c *gin.Context) {
+	var request exportRequest.TaskExportRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	actor := policy.GetActor(c)
+
+	var err error
+	switch {
+	case request.ProjectID > 0:
+		err = policy.CanAccessProject(c.Request.Context(), actor, request.ProjectID)
+	case request.AssignedTo > 0:
+		err = policy.CanAccessUser(actor, request.AssignedTo)
+	default:
+		err = policy.RequireManager(actor)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	startExport(c, request.ToCreateExportParams(actor.UserID).(database.CreateExportParams))
+}
+
+// ExportProjects solicita a exportação dos projetos. Assim como na listagem, usuários que não
+// são gerentes exportam apenas os projetos dos quais são membros.
+func ExportProjects(c *gin.Context) {
+	var request exportRequest.ProjectExportRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	actor := policy.GetActor(c)
+
+	switch {
+	case request.UserID > 0:
+		if err := policy.CanAccessUser(actor, request.UserID); err != nil {
+			c.Error(err)
+			return
+		}
+	case request.ClientID > 0:
+		if err := policy.RequireManager(actor); err != nil {
+			c.Error(err)
+			return
+		}
+	case !actor.IsManager():
+		request.UserID = actor.UserID
+	}
+
+	startExport(c, request.ToCreateExportParams(actor.UserID).(database.CreateExportParams))
+}
+
+// ExportShipments solicita a exportação dos embarques (apenas gerentes)
+func ExportShipments(c *gin.Context) {
+	actor := policy.GetActor(c)
+	if err := policy.RequireManager(actor); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var request exportRequest.ShipmentExportRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	startExport(c, request.ToCreateExportParams(actor.UserID).(database.CreateExportParams))
+}
+
+// GetExports retorna as exportações do usuário autenticado com paginação
+func GetExports(c *gin.Context) {
+	// Parâmetros de paginação
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	result, err := exportRepository.GetExportsByUserWithPagination(c.Request.Context(), policy.GetActor(c).UserID, page, limit)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar exportações"))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetExport retorna a situação de uma exportação e, quando concluída, o link de download
+func GetExport(c *gin.Context) {
+	export, ok := findExport(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, exportEntity.FromDatabaseExport(export))
+}
+
+// DownloadExport baixa o arquivo gerado pela exportação
+func DownloadExport(c *gin.Context) {
+	export, ok := findExport(c)
+	if !ok {
+		return
+	}
+
+	key, filename, err := exportService.File(export)
+	if err != nil {
+		c.Error(apperror.Conflict("A exportação ainda não foi concluída"))
+		return
+	}
+
+	fileHandler.ServeFile(c, key, filename, "", "")
+}
+
+// startExport registra a exportação, enfileira a geração do arquivo e responde com 202
+func startExport(c *gin.Context, params database.CreateExportParams) {
+	export, err := exportService.Start(c.Request.Context(), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao solicitar exportação"))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, exportEntity.FromDatabaseExport(export))
+}
+
+// findExport busca a exportação do parâmetro :id e confere se o usuário pode acessá-la
+func findExport(c *gin.Context) (database.Export, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return database.Export{}, false
+	}
+
+	export, err := exportRepository.GetExport(c.Request.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Exportação não encontrada"))
+		return database.Export{}, false
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar exportação"))
+		return database.Export{}, false
+	}
+
+	if err := policy.CanAccessExport(policy.GetActor(c), export); err != nil {
+		c.Error(err)
+		return database.Export{}, false
+	}
+
+	return export, true
+}
@@ -1,28 +1,116 @@
 package fileHandler
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
-	"os"
-	"path/filepath"
-	"sixTask/config/storageProvider"
+	"path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"sixTask/config/storageProvider"
+	signedurl "sixTask/helpers/signedUrl"
+	"sixTask/internal/http/apperror"
 )
 
-// GetFileHandler returns a handler function to serve files from storage
+// GetFileHandler returns a handler function to serve files from storage.
+// Only links signed by storageProvider (Storage.URL) are accepted.
 func GetFileHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		filePath := c.Param("filepath")
-		basePath := storageProvider.StorageBasePath
-		fullPath := filepath.Join(basePath, filePath)
+		key, err := storageProvider.CleanKey(c.Param("filepath"))
+		if err != nil {
+			c.Error(apperror.NotFound("Arquivo não encontrado"))
+			return
+		}
 
-		// Check if file exists
-		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			c.AbortWithStatus(http.StatusNotFound)
+		if err := signedurl.Verify(storageProvider.StorageResource(key), c.Request.URL.Query()); err != nil {
+			c.Error(apperror.Forbidden(err.Error()))
 			return
 		}
 
-		// Serve the file
-		c.File(fullPath)
+		ServeFile(c, key, path.Base(key), "", "")
+	}
+}
+
+// ServeFile envia o arquivo do storage com o nome de download informado.
+// contentType vazio usa o tipo informado pelo storage. Suporta requisições com Range e,
+// quando etag é informado, requisições condicionais. Com ?disposition=inline o navegador
+// exibe o arquivo em vez de baixá-lo, apenas para tipos seguros (imagens, áudio, vídeo, PDF e texto).
+func ServeFile(c *gin.Context, key, downloadName, contentType, etag string) {
+	ctx := c.Request.Context()
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		c.Error(apperror.Unavailable("Storage indisponível").WithCause(err))
+		return
+	}
+
+	// Check if file exists
+	info, err := store.Stat(ctx, key)
+	if errors.Is(err, storageProvider.ErrNotFound) || errors.Is(err, storageProvider.ErrInvalidKey) {
+		c.Error(apperror.NotFound("Arquivo não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao ler arquivo"))
+		return
+	}
+
+	file, err := store.Get(ctx, key)
+	if errors.Is(err, storageProvider.ErrNotFound) {
+		c.Error(apperror.NotFound("Arquivo não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao ler arquivo"))
+		return
+	}
+	defer file.Close()
+
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+
+	disposition := "attachment"
+	if c.Query("disposition") == "inline" && isInlineSafe(contentType) {
+		disposition = "inline"
+	}
+
+	// mime.FormatMediaType codifica nomes com acentos (filename*=utf-8'')
+	c.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": downloadName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	if contentType != "" {
+		c.Header("Content-Type", contentType)
+	}
+	if etag != "" {
+		c.Header("ETag", `"`+etag+`"`)
+	}
+
+	// Serve the file, com suporte a Range quando o driver permite
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, downloadName, info.ModTime, seeker)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, info.Size, contentType, file, nil)
+}
+
+// isInlineSafe indica se o tipo pode ser exibido no navegador sem risco de executar scripts
+func isInlineSafe(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "audio/"),
+		strings.HasPrefix(mediaType, "video/"),
+		mediaType == "application/pdf",
+		mediaType == "text/plain":
+		return true
+	default:
+		return false
 	}
 }
@@ -0,0 +1,45 @@
+package healthhandler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/metrics"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/service/healthService"
+)
+
+// Healthz indica que o processo está no ar. Não consulta dependências: uma queda do banco
+// ou do Redis não deve fazer o orquestrador reiniciar o processo, apenas tirá-lo do balanceamento (ver Readyz).
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": healthService.StatusOK})
+}
+
+// Readyz verifica o banco, o Redis e o storage, respondendo 503 se algum estiver indisponível
+func Readyz(c *gin.Context) {
+	report := healthService.Ready(c.Request.Context(), healthService.Checks())
+	if !report.OK() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Metrics expõe as métricas no formato do Prometheus. Com METRICS_TOKEN definido,
+// exige o cabeçalho Authorization: Bearer <token>.
+func Metrics(c *gin.Context) {
+	if token := appConfig.Get().Metrics.Token; token != "" {
+		given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.Error(apperror.Unauthorized("Token de métricas inválido"))
+			return
+		}
+	}
+
+	metrics.Handler().ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,142 @@
+package importHandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/handler/fileHandler"
+	"sixTask/internal/http/request/importRequest"
+	uploadmiddleware "sixTask/internal/middleware/uploadMiddleware"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/importRepository"
+	"sixTask/internal/service/importService"
+)
+
+// GetImports retorna as importações com paginação, das mais recentes para as mais antigas
+func GetImports(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Parâmetros de paginação
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	result, err := importRepository.GetImportsWithPagination(c.Request.Context(), page, limit)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar importações"))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetImport retorna a situação e os contadores de uma importação
+func GetImport(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	imp, ok := findImport(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, imp)
+}
+
+// CreateImport recebe a planilha e enfileira a importação na fila de planilhas.
+// A resposta é imediata (202); acompanhe o andamento por GET /imports/:id.
+func CreateImport(c *gin.Context) {
+	actor := policy.GetActor(c)
+	if err := policy.RequireManager(actor); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var request importRequest.CreateImportRequest
+	if err := c.ShouldBind(&request); err != nil {
+		if uploadmiddleware.IsBodyTooLarge(err) {
+			c.Error(apperror.TooLarge("Arquivo excede o tamanho máximo permitido"))
+			return
+		}
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	imp, err := importService.Start(c.Request.Context(), request, actor.UserID)
+	if errors.Is(err, importService.ErrTemplateNotFound) {
+		c.Error(apperror.NotFound("Template não encontrado"))
+		return
+	}
+	if errors.Is(err, importService.ErrInvalidTemplate) {
+		c.Error(apperror.Unprocessable(err.Error()))
+		return
+	}
+	if errors.Is(err, storageProvider.ErrFileTooLarge) {
+		c.Error(apperror.TooLarge(err.Error()))
+		return
+	}
+	if errors.Is(err, importService.ErrUnsupportedFile) || errors.Is(err, storageProvider.ErrTypeNotAllowed) {
+		c.Error(apperror.UnsupportedMediaType(err.Error()))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao criar importação"))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, imp)
+}
+
+// GetImportErrors baixa o relatório CSV com os erros por linha da importação
+func GetImportErrors(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	imp, ok := findImport(c)
+	if !ok {
+		return
+	}
+
+	key, err := importService.Report(imp)
+	if err != nil {
+		c.Error(apperror.NotFound("A importação não possui relatório de erros"))
+		return
+	}
+
+	fileHandler.ServeFile(c, key, fmt.Sprintf("importacao-%d-erros.csv", imp.ID), "text/csv; charset=utf-8", "")
+}
+
+// findImport busca a importação do parâmetro :id, respondendo com o erro adequado quando não encontrada
+func findImport(c *gin.Context) (database.Import, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return database.Import{}, false
+	}
+
+	imp, err := importRepository.GetImport(c.Request.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Importação não encontrada"))
+		return database.Import{}, false
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar importação"))
+		return database.Import{}, false
+	}
+
+	return imp, true
+}
@@ -0,0 +1,237 @@
+package importTemplateHandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/entity/templateEntity"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/templateRequest"
+	uploadmiddleware "sixTask/internal/middleware/uploadMiddleware"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/templateRepository"
+	"sixTask/internal/service/importService"
+)
+
+// GetTemplates retorna os templates de importação com paginação
+func GetTemplates(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Parâmetros de paginação
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	result, err := templateRepository.GetTemplatesWithPagination(c.Request.Context(), page, limit)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar templates"))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTemplate retorna um template de importação pelo ID
+func GetTemplate(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, ok := findTemplate(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, templateEntity.FromDatabaseTemplate(template))
+}
+
+// CreateTemplate cria um template de importação depois de conferir a tabela e as colunas com o banco
+func CreateTemplate(c *gin.Context) {
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var request templateRequest.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := importService.ValidateTemplate(ctx, request.Tabela, request.ColunaIdentificacao, request.Colunas)
+	if respondValidationError(c, err) {
+		return
+	}
+
+	template, err := templateRepository.CreateTemplate(ctx, request.ToCreateTemplateParams().(database.CreateTemplateParams))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao criar template"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, templateEntity.FromDatabaseTemplate(template))
+}
+
+// UpdateTemplate substitui os dados de um template de importação
+func UpdateTemplate(c *gin.Context) {
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	var request templateRequest.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err = importService.ValidateTemplate(ctx, request.Tabela, request.ColunaIdentificacao, request.Colunas)
+	if respondValidationError(c, err) {
+		return
+	}
+
+	template, err := templateRepository.UpdateTemplate(ctx, request.ToUpdateTemplateParams(id).(database.UpdateTemplateParams))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Template não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao atualizar template"))
+		return
+	}
+
+	c.JSON(http.StatusOK, templateEntity.FromDatabaseTemplate(template))
+}
+
+// DeleteTemplate remove um template de importação
+func DeleteTemplate(c *gin.Context) {
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	err = templateRepository.DeleteTemplate(c.Request.Context(), id)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao remover template"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template removido com sucesso"})
+}
+
+// DryRunTemplate simula a importação de uma planilha de exemplo com o template, sem gravar dados
+func DryRunTemplate(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var request templateRequest.DryRunTemplateRequest
+	if err := c.ShouldBind(&request); err != nil {
+		if uploadmiddleware.IsBodyTooLarge(err) {
+			c.Error(apperror.TooLarge("Arquivo excede o tamanho máximo permitido"))
+			return
+		}
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	template, ok := findTemplate(c)
+	if !ok {
+		return
+	}
+
+	file, err := request.File.Open()
+	if err != nil {
+		c.Error(apperror.BadRequest("Erro ao ler arquivo: " + err.Error()))
+		return
+	}
+	defer file.Close()
+
+	if _, err := importService.Policy().Check(file, request.File.Size); err != nil {
+		respondFileError(c, err)
+		return
+	}
+
+	result, err := importService.DryRun(c.Request.Context(), template, request.Filename(), file)
+	if respondValidationError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// findTemplate busca o template do parâmetro :id, respondendo com o erro adequado quando não encontrado
+func findTemplate(c *gin.Context) (database.Template, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID inválido"))
+		return database.Template{}, false
+	}
+
+	template, err := templateRepository.GetTemplate(c.Request.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Template não encontrado"))
+		return database.Template{}, false
+	}
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao buscar template"))
+		return database.Template{}, false
+	}
+
+	return template, true
+}
+
+// respondValidationError responde aos erros da validação ou da simulação do template.
+// Retorna false quando não houve erro.
+func respondValidationError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, importService.ErrInvalidTemplate), errors.Is(err, importService.ErrInvalidSpreadsheet):
+		c.Error(apperror.Unprocessable(err.Error()))
+	case errors.Is(err, importService.ErrUnsupportedFile):
+		respondFileError(c, err)
+	default:
+		c.Error(apperror.Wrap(err, "Erro ao validar template"))
+	}
+
+	return true
+}
+
+// respondFileError responde aos erros da planilha enviada para a simulação
+func respondFileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, storageProvider.ErrFileTooLarge):
+		c.Error(apperror.TooLarge(err.Error()))
+	case errors.Is(err, storageProvider.ErrTypeNotAllowed), errors.Is(err, importService.ErrUnsupportedFile):
+		c.Error(apperror.UnsupportedMediaType(err.Error()))
+	default:
+		c.Error(apperror.BadRequest(err.Error()))
+	}
+}
@@ -0,0 +1,65 @@
+package mailPreviewHandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/internal/http/apperror"
+)
+
+// ListTemplates lista os templates de email disponíveis e seus idiomas
+func ListTemplates(c *gin.Context) {
+	names, err := emailprovider.TemplateNames()
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Erro ao carregar templates"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": names})
+}
+
+// PreviewTemplate renderiza um template de email para conferência no navegador.
+// Os parâmetros da query (exceto locale e format) são usados como dados do template,
+// ex.: /api/dev/mail/password_reset?locale=en&format=text&Nome=Ana&ExpiraEmMinutos=60
+func PreviewTemplate(c *gin.Context) {
+	data := make(map[string]interface{})
+	for key, values := range c.Request.URL.Query() {
+		if key == "locale" || key == "format" || len(values) == 0 {
+			continue
+		}
+
+		// Números são convertidos para que funções como duration funcionem na prévia
+		if number, err := strconv.ParseFloat(values[0], 64); err == nil {
+			data[key] = number
+			continue
+		}
+		data[key] = values[0]
+	}
+
+	rendered, err := emailprovider.Render(c.Param("template"), c.Query("locale"), data)
+	if errors.Is(err, emailprovider.ErrTemplateNotFound) {
+		c.Error(apperror.NotFound("Template não encontrado"))
+		return
+	}
+	if err != nil {
+		c.Error(apperror.Unprocessable("Erro ao renderizar template: " + err.Error()))
+		return
+	}
+
+	switch c.DefaultQuery("format", "html") {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte("Assunto: "+rendered.Subject+"\n\n"+rendered.Text))
+	case "json":
+		c.JSON(http.StatusOK, gin.H{
+			"subject": rendered.Subject,
+			"html":    rendered.HTML,
+			"text":    rendered.Text,
+		})
+	default:
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(rendered.HTML))
+	}
+}
@@ -1,25 +1,38 @@
 package notificationHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/policy"
 )
 
 // GetNotifications retorna todas as notificações
 func GetNotifications(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	notifications, err := queries.FindManyNotifications(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar notificações: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar notificações"))
 		return
 	}
 
@@ -28,19 +41,34 @@ func GetNotifications(c *gin.Context) {
 
 // GetNotification retorna uma notificação pelo ID
 func GetNotification(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessNotification(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	notification, err := queries.FindNotificationById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Notificação não encontrada"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Notificação não encontrada"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar notificação"))
 		return
 	}
 
@@ -49,14 +77,25 @@ func GetNotification(c *gin.Context) {
 
 // GetNotificationsByUser retorna notificações pelo ID do usuário
 func GetNotificationsByUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
+
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
@@ -64,7 +103,7 @@ func GetNotificationsByUser(c *gin.Context) {
 	queries := database.New(conn)
 	notifications, err := queries.FindNotificationsByUserId(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar notificações: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar notificações"))
 		return
 	}
 
@@ -73,14 +112,25 @@ func GetNotificationsByUser(c *gin.Context) {
 
 // GetUnreadNotificationsByUser retorna notificações não lidas pelo ID do usuário
 func GetUnreadNotificationsByUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
+
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
@@ -88,7 +138,7 @@ func GetUnreadNotificationsByUser(c *gin.Context) {
 	queries := database.New(conn)
 	notifications, err := queries.FindUnreadNotificationsByUserId(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar notificações: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar notificações"))
 		return
 	}
 
@@ -97,20 +147,31 @@ func GetUnreadNotificationsByUser(c *gin.Context) {
 
 // GetNotificationsByNotifiable retorna notificações pelo tipo e ID do objeto notificável
 func GetNotificationsByNotifiable(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	notifiableType := c.Param("notifiable_type")
 	if notifiableType == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo de objeto notificável inválido"})
+		c.Error(apperror.BadRequest("Tipo de objeto notificável inválido"))
 		return
 	}
 
 	notifiableId, err := strconv.ParseInt(c.Param("notifiable_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objeto notificável inválido"})
+		c.Error(apperror.BadRequest("ID do objeto notificável inválido"))
+		return
+	}
+
+	if err := policy.CanAccessMorph(ctx, policy.GetActor(c), notifiableType, notifiableId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	params := database.FindNotificationsByNotifiableParams{
 		NotifiableType: notifiableType,
@@ -120,7 +181,7 @@ func GetNotificationsByNotifiable(c *gin.Context) {
 	queries := database.New(conn)
 	notifications, err := queries.FindNotificationsByNotifiable(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar notificações: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar notificações"))
 		return
 	}
 
@@ -129,19 +190,30 @@ func GetNotificationsByNotifiable(c *gin.Context) {
 
 // CreateNotification cria uma nova notificação
 func CreateNotification(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
+
+	if err := policy.CanManageNotifications(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
 
 	var params database.CreateNotificationParams
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
 	queries := database.New(conn)
 	notification, err := queries.CreateNotification(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar notificação: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar notificação"))
 		return
 	}
 
@@ -150,18 +222,29 @@ func CreateNotification(c *gin.Context) {
 
 // UpdateNotification atualiza uma notificação existente
 func UpdateNotification(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanManageNotifications(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	var params database.UpdateNotificationParams
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
 		return
 	}
 	params.ID = id
@@ -169,7 +252,7 @@ func UpdateNotification(c *gin.Context) {
 	queries := database.New(conn)
 	notification, err := queries.UpdateNotification(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar notificação: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar notificação"))
 		return
 	}
 
@@ -178,19 +261,30 @@ func UpdateNotification(c *gin.Context) {
 
 // MarkNotificationAsRead marca uma notificação como lida
 func MarkNotificationAsRead(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
+	if err := policy.CanAccessNotification(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	notification, err := queries.MarkNotificationAsRead(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao marcar notificação como lida: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao marcar notificação como lida"))
 		return
 	}
 
@@ -199,22 +293,33 @@ func MarkNotificationAsRead(c *gin.Context) {
 
 // MarkAllNotificationsAsRead marca todas as notificações de um usuário como lidas
 func MarkAllNotificationsAsRead(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
 		return
 	}
 
+	if err := policy.CanActAsUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
 
 	queries := database.New(conn)
 	notifications, err := queries.MarkAllNotificationsAsRead(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao marcar notificações como lidas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao marcar notificações como lidas"))
 		return
 	}
 
@@ -223,19 +328,30 @@ func MarkAllNotificationsAsRead(c *gin.Context) {
 
 // DeleteNotification remove uma notificação
 func DeleteNotification(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessNotification(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	err = queries.DeleteNotification(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover notificação: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover notificação"))
 		return
 	}
 
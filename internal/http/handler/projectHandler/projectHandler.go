@@ -1,18 +1,21 @@
 package projectHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"sixTask/internal/entity/projectEntity"
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/repository/projectRepository"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
 	"sixTask/internal/http/request/projectRequest"
-	"sixTask/internal/http/validator"
+	"sixTask/internal/policy"
+	"sixTask/internal/service/attachmentService"
 )
 
 // GetProjects retorna todos os projetos
@@ -21,10 +24,17 @@ func GetProjects(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
 
+	// Gerentes veem todos os projetos; os demais usuários apenas os projetos dos quais são membros
+	actor := policy.GetActor(c)
+	if !actor.IsManager() {
+		respondProjectsByUser(c, actor.UserID, page, limit)
+		return
+	}
+
 	// Chamar repositório para buscar projetos com paginação
-	projects, total, err := projectRepository.GetProjectsWithUsersAndPagination(page, limit)
+	projects, total, err := projectRepository.GetProjectsWithUsersAndPagination(c.Request.Context(), page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar projetos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar projetos"))
 		return
 	}
 
@@ -45,19 +55,34 @@ func GetProjects(c *gin.Context) {
 
 // GetProject retorna um projeto pelo ID
 func GetProject(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
+	if err := policy.CanAccessProject(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	project, err := queries.FindProjectWithUsers(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Projeto não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Projeto não encontrado"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar projeto"))
 		return
 	}
 
@@ -70,9 +95,14 @@ func GetProjectsByClient(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
 
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
 	clientId, err := strconv.ParseInt(c.Param("client_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente inválido"})
+		c.Error(apperror.BadRequest("ID do cliente inválido"))
 		return
 	}
 
@@ -80,9 +110,9 @@ func GetProjectsByClient(c *gin.Context) {
 	clientIdPg := pgtype.Int8{Int64: clientId, Valid: true}
 
 	// Chamar repositório para buscar projetos com paginação
-	projects, total, err := projectRepository.GetProjectsByClientIdAndPagination(clientIdPg, page, limit)
+	projects, total, err := projectRepository.GetProjectsByClientIdAndPagination(c.Request.Context(), clientIdPg, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar projetos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar projetos"))
 		return
 	}
 
@@ -108,16 +138,30 @@ func GetProjectsByUser(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
 	user_id, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
 
+	if err := policy.CanAccessUser(policy.GetActor(c), user_id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondProjectsByUser(c, user_id, page, limit)
+}
+
+// respondProjectsByUser responde com os projetos paginados dos quais o usuário é membro
+func respondProjectsByUser(c *gin.Context, userId int64, page, limit int) {
 	userPgId := pgtype.Int8{
-		Int64: user_id,
+		Int64: userId,
 		Valid: true,
 	}
 
 	// Chamar repositório para buscar projetos com paginação
-	projects, total, err := projectRepository.GetProjectsByUserIdAndPagination(userPgId, page, limit)
+	projects, total, err := projectRepository.GetProjectsByUserIdAndPagination(c.Request.Context(), userPgId, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar projetos: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar projetos"))
 		return
 	}
 
@@ -138,17 +182,21 @@ func GetProjectsByUser(c *gin.Context) {
 
 // CreateProject cria um novo projeto
 func CreateProject(c *gin.Context) {
+	if err := policy.CanManageProjects(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
 
 	var request projectRequest.CreateProjectRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
-	project, users, err := projectRepository.CreateProject(request)
-
+	project, users, err := projectRepository.CreateProject(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar projeto: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar projeto"))
+		return
 	}
 
 	response := projectEntity.GetProjectEntity(project, users)
@@ -158,22 +206,27 @@ func CreateProject(c *gin.Context) {
 
 // UpdateProject atualiza um projeto existente
 func UpdateProject(c *gin.Context) {
+	if err := policy.CanManageProjects(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
 	var request projectRequest.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
 	// Chama o repositório para atualizar o projeto e gerenciar as relações com usuários
-	project, users, err := projectRepository.UpdateProjectWithUsers(request, id)
+	project, users, err := projectRepository.UpdateProjectWithUsers(c.Request.Context(), request, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar projeto: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar projeto"))
 		return
 	}
 
@@ -185,21 +238,26 @@ func UpdateProject(c *gin.Context) {
 
 // DeleteProject remove um projeto
 func DeleteProject(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.CanManageProjects(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	err = queries.DeleteProject(ctx, id)
+	// Remove o projeto e os registros relacionados em uma única transação
+	attachments, err := projectRepository.DeleteProject(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover projeto: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover projeto"))
 		return
 	}
 
+	// Os arquivos dos anexos removidos só são apagados do storage depois do commit
+	attachmentService.RemoveFiles(c.Request.Context(), attachments)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Projeto removido com sucesso"})
 }
@@ -1,26 +1,41 @@
 package subtaskHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/http/request/subtaskRequest"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/subtaskRepository"
+	"sixTask/internal/service/attachmentService"
 )
 
 // GetSubtasks retorna todas as subtarefas
 func GetSubtasks(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	subtasks, err := queries.FindManySubtasks(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar subtarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar subtarefas"))
 		return
 	}
 
@@ -29,19 +44,34 @@ func GetSubtasks(c *gin.Context) {
 
 // GetSubtask retorna uma subtarefa pelo ID
 func GetSubtask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessSubtask(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
 		return
 	}
 
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	subtask, err := queries.FindSubtaskById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Subtarefa não encontrada"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Subtarefa não encontrada"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar subtarefa"))
 		return
 	}
 
@@ -50,22 +80,33 @@ func GetSubtask(c *gin.Context) {
 
 // GetSubtasksByTask retorna subtarefas pelo ID da tarefa
 func GetSubtasksByTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	taskId, err := strconv.ParseInt(c.Param("task_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da tarefa inválido"})
+		c.Error(apperror.BadRequest("ID da tarefa inválido"))
 		return
 	}
 
+	if err := policy.CanAccessTask(ctx, policy.GetActor(c), taskId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converter int64 para pgtype.Int8
 	taskIdPg := pgtype.Int8{Int64: taskId, Valid: true}
 
 	queries := database.New(conn)
 	subtasks, err := queries.FindSubtasksByTaskId(ctx, taskIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar subtarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar subtarefas"))
 		return
 	}
 
@@ -74,14 +115,25 @@ func GetSubtasksByTask(c *gin.Context) {
 
 // GetSubtasksByAssignedTo retorna subtarefas pelo ID do usuário atribuído
 func GetSubtasksByAssignedTo(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
+
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
@@ -89,7 +141,7 @@ func GetSubtasksByAssignedTo(c *gin.Context) {
 	queries := database.New(conn)
 	subtasks, err := queries.FindSubtasksByAssignedTo(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar subtarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar subtarefas"))
 		return
 	}
 
@@ -98,19 +150,30 @@ func GetSubtasksByAssignedTo(c *gin.Context) {
 
 // GetSubtasksByStatus retorna subtarefas pelo status
 func GetSubtasksByStatus(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	status := c.Param("status")
 	if status == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido"})
+		c.Error(apperror.BadRequest("Status inválido"))
+		return
+	}
+
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	subtasks, err := queries.FindSubtasksByStatus(ctx, status)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar subtarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar subtarefas"))
 		return
 	}
 
@@ -119,22 +182,33 @@ func GetSubtasksByStatus(c *gin.Context) {
 
 // CreateSubtask cria uma nova subtarefa
 func CreateSubtask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	var request subtaskRequest.CreateSubtaskRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
 		return
 	}
 
+	if err := policy.CanCreateInTask(ctx, policy.GetActor(c), request.TaskID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converte a request para o formato esperado pelo sqlc
 	params := request.ToCreateSubtaskParams().(database.CreateSubtaskParams)
 
 	queries := database.New(conn)
 	subtask, err := queries.CreateSubtask(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar subtarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar subtarefa"))
 		return
 	}
 
@@ -143,28 +217,44 @@ func CreateSubtask(c *gin.Context) {
 
 // UpdateSubtask atualiza uma subtarefa existente
 func UpdateSubtask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
 	var request subtaskRequest.UpdateSubtaskRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := policy.CanAccessSubtask(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
 		return
 	}
 
+	if err := policy.CanCreateInTask(ctx, policy.GetActor(c), request.TaskID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converte a request para o formato esperado pelo sqlc
 	params := request.ToUpdateSubtaskParams(id).(database.UpdateSubtaskParams)
 
 	queries := database.New(conn)
 	subtask, err := queries.UpdateSubtask(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar subtarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar subtarefa"))
 		return
 	}
 
@@ -173,19 +263,30 @@ func UpdateSubtask(c *gin.Context) {
 
 // CompleteSubtask marca uma subtarefa como concluída
 func CompleteSubtask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessSubtask(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	subtask, err := queries.CompleteSubtask(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao concluir subtarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao concluir subtarefa"))
 		return
 	}
 
@@ -194,21 +295,25 @@ func CompleteSubtask(c *gin.Context) {
 
 // DeleteSubtask remove uma subtarefa
 func DeleteSubtask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	err = queries.DeleteSubtask(ctx, id)
+	if err := policy.CanDeleteTask(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachments, err := subtaskRepository.DeleteSubtask(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover subtarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover subtarefa"))
 		return
 	}
 
+	// Os arquivos dos anexos removidos só são apagados do storage depois do commit
+	attachmentService.RemoveFiles(c.Request.Context(), attachments)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Subtarefa removida com sucesso"})
 }
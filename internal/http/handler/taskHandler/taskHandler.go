@@ -1,20 +1,30 @@
 package taskHandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
-	"sixTask/internal/http/validator"
+	"sixTask/internal/entity/taskEntity"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/taskRequest"
+	"sixTask/internal/policy"
 	"sixTask/internal/repository/taskRepository"
+	"sixTask/internal/service/attachmentService"
 )
 
 // GetTasks retorna todas as tarefas com paginação e informações de usuário
 func GetTasks(c *gin.Context) {
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Extrair parâmetros de paginação da query
 	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
 	if err != nil || page < 1 {
@@ -27,9 +37,9 @@ func GetTasks(c *gin.Context) {
 	}
 
 	// Usar o repository para buscar tarefas com paginação e informações de usuário
-	tasks, err := taskRepository.GetTasksWithPaginationAndUsers(context.Background(), page, limit)
+	tasks, err := taskRepository.GetTasksWithPaginationAndUsers(c.Request.Context(), page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefas"))
 		return
 	}
 
@@ -38,19 +48,34 @@ func GetTasks(c *gin.Context) {
 
 // GetTask retorna uma tarefa pelo ID
 func GetTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
+	if err := policy.CanAccessTask(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	task, err := queries.FindTaskById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Tarefa não encontrada"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tarefa não encontrada"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefa"))
 		return
 	}
 
@@ -59,22 +84,33 @@ func GetTask(c *gin.Context) {
 
 // GetTasksByProject retorna tarefas pelo ID do projeto
 func GetTasksByProject(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	projectId, err := strconv.ParseInt(c.Param("project_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do projeto inválido"})
+		c.Error(apperror.BadRequest("ID do projeto inválido"))
+		return
+	}
+
+	if err := policy.CanAccessProject(ctx, policy.GetActor(c), projectId); err != nil {
+		c.Error(err)
 		return
 	}
 
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	// Converter int64 para pgtype.Int8
 	projectIdPg := pgtype.Int8{Int64: projectId, Valid: true}
 
 	queries := database.New(conn)
 	tasks, err := queries.FindTasksByProjectId(ctx, projectIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefas"))
 		return
 	}
 
@@ -83,14 +119,25 @@ func GetTasksByProject(c *gin.Context) {
 
 // GetTasksByAssignedTo retorna tarefas pelo ID do usuário atribuído
 func GetTasksByAssignedTo(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	userId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		c.Error(apperror.BadRequest("ID do usuário inválido"))
+		return
+	}
+
+	if err := policy.CanAccessUser(policy.GetActor(c), userId); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	// Converter int64 para pgtype.Int8
 	userIdPg := pgtype.Int8{Int64: userId, Valid: true}
@@ -98,7 +145,7 @@ func GetTasksByAssignedTo(c *gin.Context) {
 	queries := database.New(conn)
 	tasks, err := queries.FindTasksByAssignedTo(ctx, userIdPg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefas"))
 		return
 	}
 
@@ -107,19 +154,30 @@ func GetTasksByAssignedTo(c *gin.Context) {
 
 // GetTasksByStatus retorna tarefas pelo status
 func GetTasksByStatus(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	status := c.Param("status")
 	if status == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido"})
+		c.Error(apperror.BadRequest("Status inválido"))
+		return
+	}
+
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	tasks, err := queries.FindTasksByStatus(ctx, status)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefas"))
 		return
 	}
 
@@ -128,19 +186,30 @@ func GetTasksByStatus(c *gin.Context) {
 
 // GetTasksByPriority retorna tarefas pela prioridade
 func GetTasksByPriority(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	priority := c.Param("priority")
 	if priority == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Prioridade inválida"})
+		c.Error(apperror.BadRequest("Prioridade inválida"))
 		return
 	}
 
+	if err := policy.RequireManager(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Release()
+
 	queries := database.New(conn)
 	tasks, err := queries.FindTasksByPriority(ctx, priority)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar tarefas"))
 		return
 	}
 
@@ -149,68 +218,87 @@ func GetTasksByPriority(c *gin.Context) {
 
 // CreateTask cria uma nova tarefa
 func CreateTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	var request taskRequest.CreateTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
 
-	var params database.CreateTaskParams
-	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+	if err := policy.CanCreateInProject(c.Request.Context(), policy.GetActor(c), request.ProjectID); err != nil {
+		c.Error(err)
 		return
 	}
 
-	queries := database.New(conn)
-	task, err := queries.CreateTask(ctx, params)
+	// Cria a tarefa e as relações com usuários em uma única transação
+	task, users, err := taskRepository.CreateTaskWithUsers(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar tarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar tarefa"))
 		return
 	}
 
-	c.JSON(http.StatusCreated, task)
+	c.JSON(http.StatusCreated, taskEntity.GetTaskEntity(task, users))
 }
 
 // UpdateTask atualiza uma tarefa existente
 func UpdateTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	var params database.UpdateTaskParams
-	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + validator.Translate(err)})
+	if err := policy.CanAccessTask(c.Request.Context(), policy.GetActor(c), id); err != nil {
+		c.Error(err)
 		return
 	}
-	params.ID = id
 
-	queries := database.New(conn)
-	task, err := queries.UpdateTask(ctx, params)
+	var request taskRequest.UpdateTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := policy.CanCreateInProject(c.Request.Context(), policy.GetActor(c), request.ProjectID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Atualiza a tarefa e substitui as relações com usuários em uma única transação
+	task, users, err := taskRepository.UpdateTaskWithUsers(c.Request.Context(), request, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar tarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar tarefa"))
 		return
 	}
 
-	c.JSON(http.StatusOK, task)
+	c.JSON(http.StatusOK, taskEntity.GetTaskEntity(task, users))
 }
 
 // CompleteTask marca uma tarefa como concluída
 func CompleteTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
+		return
+	}
+
+	if err := policy.CanAccessTask(ctx, policy.GetActor(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	task, err := queries.CompleteTask(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao concluir tarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao concluir tarefa"))
 		return
 	}
 
@@ -219,21 +307,25 @@ func CompleteTask(c *gin.Context) {
 
 // DeleteTask remove uma tarefa
 func DeleteTask(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	err = queries.DeleteTask(ctx, id)
+	if err := policy.CanDeleteTask(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachments, err := taskRepository.DeleteTask(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao excluir tarefa: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao excluir tarefa"))
 		return
 	}
 
+	// Os arquivos dos anexos removidos só são apagados do storage depois do commit
+	attachmentService.RemoveFiles(c.Request.Context(), attachments)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Tarefa excluída com sucesso"})
 }
@@ -0,0 +1,180 @@
+package uploadHandler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/uploadSessionRequest"
+	uploadmiddleware "sixTask/internal/middleware/uploadMiddleware"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/uploadSessionRepository"
+	"sixTask/internal/service/attachmentService"
+)
+
+// ChunkContentType é o Content-Type exigido no envio dos blocos, como no protocolo tus
+const ChunkContentType = "application/offset+octet-stream"
+
+// CreateUpload abre uma sessão de upload em blocos para um novo anexo
+func CreateUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request uploadSessionRequest.CreateUploadSessionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	actor := policy.GetActor(c)
+	if err := policy.CanCreateAttachment(ctx, actor, request.OwnerID(actor.UserID), request.AttachableType, request.AttachableID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	session, err := attachmentService.StartUpload(ctx, request, actor.UserID)
+	if err != nil {
+		respondError(c, err, "Erro ao iniciar upload")
+		return
+	}
+
+	c.Header("Location", "/api/uploads/"+session.ID)
+	setUploadHeaders(c, session)
+	c.JSON(http.StatusCreated, sessionResponse(session))
+}
+
+// GetUpload retorna o andamento da sessão de upload
+func GetUpload(c *gin.Context) {
+	session, err := attachmentService.GetUpload(c.Request.Context(), c.Param("id"), policy.GetActor(c).UserID)
+	if err != nil {
+		respondError(c, err, "Erro ao buscar upload")
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.JSON(http.StatusOK, sessionResponse(session))
+}
+
+// HeadUpload informa o offset atual nos cabeçalhos, para o cliente retomar o envio
+func HeadUpload(c *gin.Context) {
+	session, err := attachmentService.GetUpload(c.Request.Context(), c.Param("id"), policy.GetActor(c).UserID)
+	if err != nil {
+		respondError(c, err, "Erro ao buscar upload")
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Status(http.StatusOK)
+}
+
+// PatchUpload recebe um bloco do arquivo a partir do offset informado em Upload-Offset
+func PatchUpload(c *gin.Context) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != ChunkContentType {
+		c.Error(apperror.UnsupportedMediaType("Content-Type deve ser " + ChunkContentType))
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.Error(apperror.BadRequest("Cabeçalho Upload-Offset inválido"))
+		return
+	}
+
+	// O tamanho do bloco precisa ser conhecido para ser gravado no storage
+	if c.Request.ContentLength <= 0 {
+		c.Error(apperror.New(http.StatusLengthRequired, apperror.CodeLengthRequired, "Content-Length é obrigatório e deve ser maior que zero"))
+		return
+	}
+
+	// Mesmo com offset divergente a sessão é retornada, para o cliente saber de onde retomar
+	session, err := attachmentService.WriteChunk(c.Request.Context(), c.Param("id"), policy.GetActor(c).UserID, offset, c.Request.Body, c.Request.ContentLength)
+	if session.ID != "" {
+		setUploadHeaders(c, session)
+	}
+	if err != nil {
+		respondError(c, err, "Erro ao gravar bloco")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// FinishUpload monta o arquivo enviado em blocos e cria o anexo
+func FinishUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := policy.GetActor(c)
+
+	// A permissão verificada em CreateUpload pode ter sido revogada enquanto os blocos eram enviados
+	attachment, err := attachmentService.FinishUpload(ctx, c.Param("id"), actor.UserID, func(session database.UploadSession) error {
+		return policy.CanCreateAttachment(ctx, actor, session.UserID, session.AttachableType, session.AttachableID)
+	})
+	if err != nil {
+		respondError(c, err, "Erro ao criar anexo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// DeleteUpload cancela a sessão de upload e descarta os blocos enviados
+func DeleteUpload(c *gin.Context) {
+	err := attachmentService.CancelUpload(c.Request.Context(), c.Param("id"), policy.GetActor(c).UserID)
+	if err != nil {
+		respondError(c, err, "Erro ao cancelar upload")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload cancelado com sucesso"})
+}
+
+// sessionResponse monta a resposta da sessão sem expor as chaves dos blocos no storage
+func sessionResponse(session database.UploadSession) gin.H {
+	return gin.H{
+		"id":              session.ID,
+		"filename":        session.Filename,
+		"size":            session.UploadLength,
+		"offset":          session.UploadOffset,
+		"attachable_type": session.AttachableType,
+		"attachable_id":   session.AttachableID,
+		"user_id":         session.UserID,
+		"max_chunk_size":  attachmentService.MaxChunkSize(),
+		"expires_at":      session.ExpiresAt.Time.UTC(),
+	}
+}
+
+// setUploadHeaders informa o andamento da sessão nos cabeçalhos usados pelo protocolo tus
+func setUploadHeaders(c *gin.Context, session database.UploadSession) {
+	c.Header("Upload-Offset", strconv.FormatInt(session.UploadOffset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.UploadLength, 10))
+	c.Header("Upload-Expires", session.ExpiresAt.Time.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-store")
+}
+
+// respondError converte os erros do upload em blocos nos status HTTP correspondentes
+func respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, attachmentService.ErrUploadSessionNotFound):
+		c.Error(apperror.NotFound("Sessão de upload não encontrada"))
+	case errors.Is(err, uploadSessionRepository.ErrOffsetMismatch):
+		c.Error(apperror.Conflict(err.Error()))
+	case errors.Is(err, attachmentService.ErrUploadIncomplete),
+		errors.Is(err, attachmentService.ErrUploadFinishing):
+		c.Error(apperror.Conflict(err.Error()))
+	case uploadmiddleware.IsBodyTooLarge(err):
+		c.Error(apperror.TooLarge("Bloco excede o tamanho máximo de " + storageProvider.FormatSize(attachmentService.MaxChunkSize())))
+	case errors.Is(err, attachmentService.ErrChunkExceedsLength),
+		errors.Is(err, storageProvider.ErrFileTooLarge),
+		errors.Is(err, attachmentService.ErrQuotaExceeded):
+		c.Error(apperror.TooLarge(err.Error()))
+	case errors.Is(err, storageProvider.ErrTypeNotAllowed):
+		c.Error(apperror.UnsupportedMediaType(err.Error()))
+	default:
+		c.Error(apperror.Wrap(err, message))
+	}
+}
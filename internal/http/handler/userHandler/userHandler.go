@@ -1,115 +1,167 @@
 package userHandler
 
 import (
-	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-
-	"sixTask/internal/database"
+	"github.com/jackc/pgx/v5"
+
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/internal/entity/userEntity"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/http/request/userRequest"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/userRepository"
+	"sixTask/internal/service/accountService"
 )
 
-// GetUsers retorna todos os usuários
+// GetUsers retorna os usuários com paginação
 func GetUsers(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
-	queries := database.New(conn)
-	users, err := queries.FindMany(ctx)
+	// Parâmetros de paginação
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	result, err := userRepository.GetUsersWithPagination(ctx, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar usuários: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao buscar usuários"))
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, result)
 }
 
 // GetUser retorna um usuário pelo ID
 func GetUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	user, err := queries.FindById(ctx, id)
+	user, err := userRepository.GetUser(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Usuário não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		c.Error(apperror.Wrap(err, "Erro ao buscar usuário"))
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, userEntity.FromDatabaseUser(user))
 }
 
 // CreateUser cria um novo usuário
 func CreateUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
 
-	var params database.CreateUserParams
-	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+	var request userRequest.CreateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
 		return
 	}
 
-	queries := database.New(conn)
-	user, err := queries.CreateUser(ctx, params)
+	user, err := userRepository.CreateUser(c.Request.Context(), request)
+	if errors.Is(err, userRepository.ErrEmailTaken) {
+		c.Error(apperror.Conflict("Email já cadastrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar usuário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao criar usuário"))
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	// A falha no envio da confirmação não desfaz o cadastro; o usuário pode pedir o reenvio
+	if err := accountService.SendEmailVerification(c.Request.Context(), user, emailprovider.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language"))); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Erro ao enviar confirmação de email", "target_user_id", user.ID, "error", err)
+	}
+
+	c.JSON(http.StatusCreated, userEntity.FromDatabaseUser(user))
 }
 
 // UpdateUser atualiza um usuário existente
 func UpdateUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+		c.Error(err)
+		return
+	}
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	var params database.UpdateUserParams
-	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+	var request userRequest.UpdateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Validation(err))
 		return
 	}
-	params.ID = id
 
-	queries := database.New(conn)
-	user, err := queries.UpdateUser(ctx, params)
+	user, err := userRepository.UpdateUser(c.Request.Context(), request, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Usuário não encontrado"))
+		return
+	}
+	if errors.Is(err, userRepository.ErrEmailTaken) {
+		c.Error(apperror.Conflict("Email já cadastrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar usuário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao atualizar usuário"))
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, userEntity.FromDatabaseUser(user))
 }
 
 // DeleteUser remove um usuário
 func DeleteUser(c *gin.Context) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	actor := policy.GetActor(c)
+	if err := policy.RequireAdmin(actor); err != nil {
+		c.Error(err)
+		return
+	}
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(apperror.BadRequest("ID inválido"))
 		return
 	}
 
-	queries := database.New(conn)
-	err = queries.DeleteUser(ctx, id)
+	// Impede que o administrador remova a própria conta e perca o acesso
+	if id == actor.UserID {
+		c.Error(apperror.Unprocessable("Não é possível remover o próprio usuário"))
+		return
+	}
+
+	err = userRepository.DeleteUser(c.Request.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Error(apperror.NotFound("Usuário não encontrado"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover usuário: " + err.Error()})
+		c.Error(apperror.Wrap(err, "Erro ao remover usuário"))
 		return
 	}
 
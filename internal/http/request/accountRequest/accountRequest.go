@@ -0,0 +1,18 @@
+package accountRequest
+
+// ForgotPasswordRequest representa o pedido de redefinição de senha
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// ResetPasswordRequest representa a troca de senha a partir do token recebido por email
+type ResetPasswordRequest struct {
+	Token                string `json:"token" binding:"required"`
+	Password             string `json:"password" binding:"required,min=8,maxbytes=72"`
+	PasswordConfirmation string `json:"password_confirmation" binding:"required,eqfield=Password"`
+}
+
+// VerifyEmailRequest representa a confirmação de email a partir do token recebido por email
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
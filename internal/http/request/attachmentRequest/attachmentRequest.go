@@ -1,52 +1,68 @@
 package attachmentRequest
 
 import (
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
 )
 
-// CreateAttachmentRequest representa os dados necessários para criar um anexo
-// com validações do gin-gonic
+// CreateAttachmentRequest representa o upload multipart de um anexo
+// com validações do gin-gonic. Sem user_id, o anexo pertence ao usuário autenticado.
 type CreateAttachmentRequest struct {
-	Filename       string      `json:"filename" binding:"required"`
-	Filepath       string      `json:"filepath" binding:"required"`
-	Filesize       int64       `json:"filesize" binding:"required,gt=0"`
-	Filetype       string      `json:"filetype" binding:"required"`
-	UserID         pgtype.Int8 `json:"user_id" binding:"required"`
-	AttachableType string      `json:"attachable_type" binding:"required"`
-	AttachableID   int64       `json:"attachable_id" binding:"required,gt=0"`
+	File           *multipart.FileHeader `form:"file" binding:"required"`
+	UserID         int64                 `form:"user_id" binding:"omitempty,gt=0"`
+	AttachableType string                `form:"attachable_type" binding:"required"`
+	AttachableID   int64                 `form:"attachable_id" binding:"required,gt=0"`
 }
 
 // UpdateAttachmentRequest representa os dados necessários para atualizar um anexo
-// com validações do gin-gonic
+// com validações do gin-gonic. O arquivo em si não pode ser trocado; envie um novo anexo.
 type UpdateAttachmentRequest struct {
-	Filename string `json:"filename" binding:"required"`
-	Filepath string `json:"filepath" binding:"required"`
-	Filesize int64  `json:"filesize" binding:"required,gt=0"`
-	Filetype string `json:"filetype" binding:"required"`
+	Filename string `json:"filename" binding:"required,max=255"`
+}
+
+// OwnerID retorna o dono do anexo: o user_id informado ou o usuário autenticado
+func (r *CreateAttachmentRequest) OwnerID(actorID int64) pgtype.Int8 {
+	if r.UserID > 0 {
+		return pgtype.Int8{Int64: r.UserID, Valid: true}
+	}
+
+	return pgtype.Int8{Int64: actorID, Valid: true}
+}
+
+// Filename retorna o nome original do arquivo, sem diretórios
+func (r *CreateAttachmentRequest) Filename() string {
+	return filepath.Base(strings.ReplaceAll(r.File.Filename, "\\", "/"))
 }
 
-// ToCreateAttachmentParams converte a request para o formato esperado pelo sqlc
-func (r *CreateAttachmentRequest) ToCreateAttachmentParams() interface{} {
+// ToCreateAttachmentParams converte a request para o formato esperado pelo sqlc,
+// usando os dados calculados pelo servidor a partir do arquivo armazenado
+func (r *CreateAttachmentRequest) ToCreateAttachmentParams(ownerID pgtype.Int8, key string, size int64, filetype, checksum, status string) interface{} {
 	return database.CreateAttachmentParams{
-		Filename:       r.Filename,
-		Filepath:       r.Filepath,
-		Filesize:       r.Filesize,
-		Filetype:       r.Filetype,
-		UserID:         r.UserID,
+		Filename:       r.Filename(),
+		Filepath:       key,
+		Filesize:       size,
+		Filetype:       filetype,
+		Checksum:       pgtype.Text{String: checksum, Valid: true},
+		Status:         status,
+		UserID:         ownerID,
 		AttachableType: r.AttachableType,
 		AttachableID:   r.AttachableID,
 	}
 }
 
-// ToUpdateAttachmentParams converte a request para o formato esperado pelo sqlc
-func (r *UpdateAttachmentRequest) ToUpdateAttachmentParams(id int64) interface{} {
+// ToUpdateAttachmentParams converte a request para o formato esperado pelo sqlc,
+// mantendo os dados do arquivo armazenado
+func (r *UpdateAttachmentRequest) ToUpdateAttachmentParams(current database.Attachment) interface{} {
 	return database.UpdateAttachmentParams{
-		Filename: r.Filename,
-		Filepath: r.Filepath,
-		Filesize: r.Filesize,
-		Filetype: r.Filetype,
-		ID:       id,
+		Filename: strings.TrimSpace(r.Filename),
+		Filepath: current.Filepath,
+		Filesize: current.Filesize,
+		Filetype: current.Filetype,
+		ID:       current.ID,
 	}
 }
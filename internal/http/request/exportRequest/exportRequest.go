@@ -0,0 +1,71 @@
+package exportRequest
+
+import (
+	"encoding/json"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/exportTypes"
+)
+
+// TaskExportRequest representa os filtros da exportação de tarefas, informados na query string
+type TaskExportRequest struct {
+	Format     string `form:"format" binding:"omitempty,oneof=xlsx csv"`
+	ProjectID  int64  `form:"project_id" binding:"omitempty,gt=0"`
+	AssignedTo int64  `form:"assigned_to" binding:"omitempty,gt=0"`
+	Status     string `form:"status" binding:"omitempty,max=50"`
+	Priority   string `form:"priority" binding:"omitempty,max=50"`
+}
+
+// ProjectExportRequest representa os filtros da exportação de projetos
+type ProjectExportRequest struct {
+	Format   string `form:"format" binding:"omitempty,oneof=xlsx csv"`
+	ClientID int64  `form:"client_id" binding:"omitempty,gt=0"`
+	UserID   int64  `form:"user_id" binding:"omitempty,gt=0"`
+}
+
+// ShipmentExportRequest representa os filtros da exportação de embarques
+type ShipmentExportRequest struct {
+	Format     string `form:"format" binding:"omitempty,oneof=xlsx csv"`
+	ShipmentID string `form:"shipment_id" binding:"omitempty,max=255"`
+}
+
+// ToCreateExportParams converte a request para o formato esperado pelo sqlc
+func (r *TaskExportRequest) ToCreateExportParams(actorID int64) interface{} {
+	return newCreateExportParams(actorID, exportTypes.Tasks, r.Format, exportTypes.Filters{
+		ProjectID:  r.ProjectID,
+		AssignedTo: r.AssignedTo,
+		Status:     r.Status,
+		Priority:   r.Priority,
+	})
+}
+
+// ToCreateExportParams converte a request para o formato esperado pelo sqlc
+func (r *ProjectExportRequest) ToCreateExportParams(actorID int64) interface{} {
+	return newCreateExportParams(actorID, exportTypes.Projects, r.Format, exportTypes.Filters{
+		ClientID: r.ClientID,
+		UserID:   r.UserID,
+	})
+}
+
+// ToCreateExportParams converte a request para o formato esperado pelo sqlc
+func (r *ShipmentExportRequest) ToCreateExportParams(actorID int64) interface{} {
+	return newCreateExportParams(actorID, exportTypes.Shipments, r.Format, exportTypes.Filters{
+		ShipmentID: r.ShipmentID,
+	})
+}
+
+// newCreateExportParams monta os parâmetros da exportação; sem formato informado gera XLSX
+func newCreateExportParams(actorID int64, resource, format string, filters exportTypes.Filters) database.CreateExportParams {
+	if format == "" {
+		format = exportTypes.XLSX
+	}
+
+	encoded, _ := json.Marshal(filters)
+
+	return database.CreateExportParams{
+		UserID:   actorID,
+		Resource: resource,
+		Format:   format,
+		Filters:  encoded,
+	}
+}
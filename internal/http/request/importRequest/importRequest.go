@@ -0,0 +1,34 @@
+package importRequest
+
+import (
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CreateImportRequest representa o upload multipart de uma planilha (XLSX ou CSV)
+// e o template que relaciona suas colunas à tabela de destino
+type CreateImportRequest struct {
+	File       *multipart.FileHeader `form:"file" binding:"required"`
+	TemplateID int64                 `form:"template_id" binding:"required,gt=0"`
+}
+
+// Filename retorna o nome original do arquivo, sem diretórios
+func (r *CreateImportRequest) Filename() string {
+	return filepath.Base(strings.ReplaceAll(r.File.Filename, "\\", "/"))
+}
+
+// ToCreateImportParams converte a request para o formato esperado pelo sqlc,
+// usando a chave do arquivo gravado no storage
+func (r *CreateImportRequest) ToCreateImportParams(actorID int64, key string) interface{} {
+	return database.CreateImportParams{
+		UserID:     pgtype.Int8{Int64: actorID, Valid: true},
+		TemplateID: pgtype.Int8{Int64: r.TemplateID, Valid: true},
+		Filename:   r.Filename(),
+		Filepath:   key,
+	}
+}
@@ -0,0 +1,60 @@
+package taskRequest
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CreateTaskRequest representa os dados necessários para criar uma tarefa
+// com validações do gin-gonic
+type CreateTaskRequest struct {
+	Title       string        `json:"title" binding:"required,min=3,max=100"`
+	Description pgtype.Text   `json:"description" binding:"omitempty"`
+	ProjectID   pgtype.Int8   `json:"project_id" binding:"omitempty"`
+	AssignedTo  pgtype.Int8   `json:"assigned_to" binding:"omitempty"`
+	Status      string        `json:"status" binding:"omitempty"`
+	Priority    string        `json:"priority" binding:"omitempty"`
+	DueDate     pgtype.Date   `json:"due_date" binding:"omitempty"`
+	UsersId     []pgtype.Int8 `json:"users_id" binding:"omitempty"`
+}
+
+// UpdateTaskRequest representa os dados necessários para atualizar uma tarefa
+// com validações do gin-gonic
+type UpdateTaskRequest struct {
+	Title       string        `json:"title" binding:"required,min=3,max=100"`
+	Description pgtype.Text   `json:"description" binding:"omitempty"`
+	ProjectID   pgtype.Int8   `json:"project_id" binding:"omitempty"`
+	AssignedTo  pgtype.Int8   `json:"assigned_to" binding:"omitempty"`
+	Status      string        `json:"status" binding:"omitempty"`
+	Priority    string        `json:"priority" binding:"omitempty"`
+	DueDate     pgtype.Date   `json:"due_date" binding:"omitempty"`
+	UsersId     []pgtype.Int8 `json:"users_id" binding:"omitempty"`
+}
+
+// ToCreateTaskParams converte a request para o formato esperado pelo sqlc
+func (r *CreateTaskRequest) ToCreateTaskParams() interface{} {
+	return database.CreateTaskParams{
+		Title:       r.Title,
+		Description: r.Description,
+		ProjectID:   r.ProjectID,
+		AssignedTo:  r.AssignedTo,
+		Status:      r.Status,
+		Priority:    r.Priority,
+		DueDate:     r.DueDate,
+	}
+}
+
+// ToUpdateTaskParams converte a request para o formato esperado pelo sqlc
+func (r *UpdateTaskRequest) ToUpdateTaskParams(id int64) interface{} {
+	return database.UpdateTaskParams{
+		Title:       r.Title,
+		Description: r.Description,
+		ProjectID:   r.ProjectID,
+		AssignedTo:  r.AssignedTo,
+		Status:      r.Status,
+		Priority:    r.Priority,
+		DueDate:     r.DueDate,
+		ID:          id,
+	}
+}
@@ -0,0 +1,64 @@
+package templateRequest
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/templateTypes"
+)
+
+// CreateTemplateRequest representa os dados necessários para criar um template de importação
+// com validações do gin-gonic. A tabela e as colunas são conferidas com o banco pelo importService.
+type CreateTemplateRequest struct {
+	Titulo              string                  `json:"titulo" binding:"required,max=255"`
+	Tabela              string                  `json:"tabela" binding:"required,max=255"`
+	ColunaIdentificacao string                  `json:"coluna_identificacao" binding:"required,max=255"`
+	Colunas             []templateTypes.Colunas `json:"colunas" binding:"required,min=1,dive"`
+}
+
+// UpdateTemplateRequest representa os dados necessários para atualizar um template de importação
+// com validações do gin-gonic. Todos os campos são substituídos.
+type UpdateTemplateRequest struct {
+	Titulo              string                  `json:"titulo" binding:"required,max=255"`
+	Tabela              string                  `json:"tabela" binding:"required,max=255"`
+	ColunaIdentificacao string                  `json:"coluna_identificacao" binding:"required,max=255"`
+	Colunas             []templateTypes.Colunas `json:"colunas" binding:"required,min=1,dive"`
+}
+
+// ToCreateTemplateParams converte a request para o formato esperado pelo sqlc
+func (r *CreateTemplateRequest) ToCreateTemplateParams() interface{} {
+	colunas, _ := json.Marshal(r.Colunas)
+
+	return database.CreateTemplateParams{
+		Titulo:              r.Titulo,
+		Tabela:              r.Tabela,
+		ColunaIdentificacao: r.ColunaIdentificacao,
+		Colunas:             colunas,
+	}
+}
+
+// ToUpdateTemplateParams converte a request para o formato esperado pelo sqlc
+func (r *UpdateTemplateRequest) ToUpdateTemplateParams(id int64) interface{} {
+	colunas, _ := json.Marshal(r.Colunas)
+
+	return database.UpdateTemplateParams{
+		Titulo:              r.Titulo,
+		Tabela:              r.Tabela,
+		ColunaIdentificacao: r.ColunaIdentificacao,
+		Colunas:             colunas,
+		ID:                  id,
+	}
+}
+
+// DryRunTemplateRequest representa o upload multipart da planilha de exemplo usada na simulação
+type DryRunTemplateRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// Filename retorna o nome original do arquivo, sem diretórios
+func (r *DryRunTemplateRequest) Filename() string {
+	return filepath.Base(strings.ReplaceAll(r.File.Filename, "\\", "/"))
+}
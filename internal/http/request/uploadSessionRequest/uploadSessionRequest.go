@@ -0,0 +1,44 @@
+package uploadSessionRequest
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CreateUploadSessionRequest representa o início de um upload em blocos
+// com validações do gin-gonic. Sem user_id, o anexo pertence ao usuário autenticado.
+type CreateUploadSessionRequest struct {
+	Filename       string `json:"filename" binding:"required,max=255"`
+	Size           int64  `json:"size" binding:"required,gt=0"`
+	UserID         int64  `json:"user_id" binding:"omitempty,gt=0"`
+	AttachableType string `json:"attachable_type" binding:"required"`
+	AttachableID   int64  `json:"attachable_id" binding:"required,gt=0"`
+}
+
+// OwnerID retorna o dono do anexo: o user_id informado ou o usuário autenticado
+func (r *CreateUploadSessionRequest) OwnerID(actorID int64) pgtype.Int8 {
+	if r.UserID > 0 {
+		return pgtype.Int8{Int64: r.UserID, Valid: true}
+	}
+
+	return pgtype.Int8{Int64: actorID, Valid: true}
+}
+
+// ToCreateUploadSessionParams converte a request para o formato esperado pelo sqlc
+func (r *CreateUploadSessionRequest) ToCreateUploadSessionParams(id string, actorID int64, expiresAt time.Time) interface{} {
+	return database.CreateUploadSessionParams{
+		ID:             id,
+		CreatedBy:      actorID,
+		UserID:         r.OwnerID(actorID),
+		AttachableType: r.AttachableType,
+		AttachableID:   r.AttachableID,
+		Filename:       filepath.Base(strings.ReplaceAll(strings.TrimSpace(r.Filename), "\\", "/")),
+		UploadLength:   r.Size,
+		ExpiresAt:      pgtype.Timestamp{Time: expiresAt.UTC(), Valid: true},
+	}
+}
@@ -0,0 +1,68 @@
+package userRequest
+
+import (
+	"strings"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/roleTypes"
+)
+
+// CreateUserRequest representa os dados necessários para criar um usuário
+// com validações do gin-gonic
+type CreateUserRequest struct {
+	Name     string `json:"name" binding:"required,min=3,max=100"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,min=8,maxbytes=72"`
+	Role     string `json:"role" binding:"omitempty,oneof=admin manager member"`
+}
+
+// UpdateUserRequest representa os dados necessários para atualizar um usuário
+// com validações do gin-gonic. Senha e papel vazios mantêm os valores atuais.
+type UpdateUserRequest struct {
+	Name     string `json:"name" binding:"required,min=3,max=100"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"omitempty,min=8,maxbytes=72"`
+	Role     string `json:"role" binding:"omitempty,oneof=admin manager member"`
+}
+
+// NormalizeEmail padroniza o email para comparação e armazenamento
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ToCreateUserParams converte a request para o formato esperado pelo sqlc.
+// A senha deve ser informada já com hash.
+func (r *CreateUserRequest) ToCreateUserParams(passwordHash string) interface{} {
+	role := r.Role
+	if role == "" {
+		role = roleTypes.Member
+	}
+
+	return database.CreateUserParams{
+		Name:     strings.TrimSpace(r.Name),
+		Email:    NormalizeEmail(r.Email),
+		Password: passwordHash,
+		Role:     role,
+	}
+}
+
+// ToUpdateUserParams converte a request para o formato esperado pelo sqlc,
+// mantendo a senha e o papel do usuário atual quando não informados
+func (r *UpdateUserRequest) ToUpdateUserParams(current database.User, passwordHash string) interface{} {
+	if passwordHash == "" {
+		passwordHash = current.Password
+	}
+
+	role := r.Role
+	if role == "" {
+		role = current.Role
+	}
+
+	return database.UpdateUserParams{
+		Name:     strings.TrimSpace(r.Name),
+		Email:    NormalizeEmail(r.Email),
+		Password: passwordHash,
+		Role:     role,
+		ID:       current.ID,
+	}
+}
@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"errors"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin/binding"
@@ -38,6 +40,15 @@ func InitValidator() {
 			return name
 		})
 
+		// maxbytes limita o tamanho em bytes, e não em caracteres (ex.: senhas, que o bcrypt limita a 72 bytes)
+		v.RegisterValidation("maxbytes", maxBytes)
+		v.RegisterTranslation("maxbytes", trans, func(ut ut.Translator) error {
+			return ut.Add("maxbytes", "{0} deve ter no máximo {1} bytes", true)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T("maxbytes", fe.Field(), fe.Param())
+			return t
+		})
+
 		// Adiciona traduções personalizadas adicionais se necessário
 		// Exemplo:
 		// v.RegisterTranslation("required", trans, func(ut ut.Translator) error {
@@ -51,6 +62,16 @@ func InitValidator() {
 	}
 }
 
+// maxBytes valida que o texto tem no máximo o número de bytes informado no parâmetro da tag
+func maxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic("validator: parâmetro inválido para maxbytes: " + fl.Param())
+	}
+
+	return len(fl.Field().String()) <= limit
+}
+
 // Translate traduz uma mensagem de erro de validação para português
 func Translate(err error) string {
 	if err == nil {
@@ -72,3 +93,19 @@ func Translate(err error) string {
 
 	return strings.Join(errMessages, "; ")
 }
+
+// Fields traduz os erros de validação para um mapa do campo (nome do JSON) para a mensagem,
+// ou retorna nil se o erro não for de validação
+func Fields(err error) map[string]string {
+	var validatorErrs validator.ValidationErrors
+	if !errors.As(err, &validatorErrs) {
+		return nil
+	}
+
+	fields := make(map[string]string, len(validatorErrs))
+	for _, e := range validatorErrs {
+		fields[e.Field()] = e.Translate(trans)
+	}
+
+	return fields
+}
@@ -0,0 +1,19 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+
+	"sixTask/internal/service/attachmentService"
+)
+
+// ExecuteCleanupUploads remove as sessões de upload em blocos abandonadas e os blocos gravados
+// no storage (ver attachmentService.CleanupUploadsJob, agendado pelo scheduler)
+func ExecuteCleanupUploads(ctx context.Context, _ struct{}) error {
+	removed, err := attachmentService.CleanupExpiredUploads(ctx)
+	if removed > 0 {
+		slog.InfoContext(ctx, "Sessões de upload expiradas removidas", "removed", removed)
+	}
+
+	return err
+}
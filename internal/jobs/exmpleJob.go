@@ -1,36 +1,66 @@
 package jobs
 
-//
-//import (
-//	"fmt"
-//
-//
-//	"context"
-//	"encoding/json"
-//	"github.com/hibiken/asynq"
-//	"log"
-//)
-//
-//const JobName = "jobModelo"
-//
-//func NewJobModel(request RequestModel.Pessoa) (*asynq.Task, error) {
-//
-//	payload, _ := json.Marshal(&request)
-//
-//	return asynq.NewTask(JobName, payload), nil
-//}
-//
-//func Execute() asynq.HandlerFunc {
-//
-//	return func(ctx context.Context, task *asynq.Task) error {
-//
-//		log.Println("processou")
-//		var payload RequestModel.Pessoa
-//
-//		json.Unmarshal(task.Payload(), &payload)
-//
-//		fmt.Println(payload)
-//		// Removido o panic para permitir a execução do job
-//		return nil
-//	}
-//}
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/queue"
+	"sixTask/internal/http/request/RequestModel"
+)
+
+// exampleSteps é a quantidade de etapas simuladas pelo job de exemplo
+const exampleSteps = 5
+
+// JobModelPayload é o payload do job de exemplo
+type JobModelPayload struct {
+	queue.Requester
+	Pessoa RequestModel.Pessoa `json:"pessoa"`
+}
+
+// JobModel é o job de exemplo disparado por POST /api/disparar-job. O resultado fica
+// disponível em /api/jobs/:id por queue.ResultRetention depois de concluído.
+var JobModel = queue.Job[JobModelPayload]{
+	Name:      "jobModelo",
+	Queue:     "default",
+	Retention: queue.ResultRetention,
+}
+
+// NewJobModel cria a tarefa do job de exemplo, levando o request_id do contexto. Usada pelo scheduler;
+// para enfileirar, use EnqueueJobModel.
+func NewJobModel(ctx context.Context, request RequestModel.Pessoa, requestedBy int64) (*asynq.Task, error) {
+	return JobModel.NewTaskContext(ctx, newJobModelPayload(request, requestedBy))
+}
+
+// EnqueueJobModel enfileira o job de exemplo, devolvendo as informações do asynq com o ID do job
+func EnqueueJobModel(ctx context.Context, request RequestModel.Pessoa, requestedBy int64) (*asynq.TaskInfo, error) {
+	return JobModel.Enqueue(ctx, newJobModelPayload(request, requestedBy))
+}
+
+// newJobModelPayload monta o payload registrando quem disparou o job
+func newJobModelPayload(request RequestModel.Pessoa, requestedBy int64) JobModelPayload {
+	return JobModelPayload{
+		Requester: queue.Requester{RequestedBy: requestedBy},
+		Pessoa:    request,
+	}
+}
+
+// Execute processa o job de exemplo em etapas, informando o andamento a cada uma
+func Execute(ctx context.Context, payload JobModelPayload) error {
+	for step := 1; step <= exampleSteps; step++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+
+		message := fmt.Sprintf("etapa %d de %d", step, exampleSteps)
+		if err := queue.ReportProgress(ctx, step*100/exampleSteps, message); err != nil {
+			return err
+		}
+	}
+
+	return queue.SetResult(ctx, "processamento concluído", payload.Pessoa)
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/queue"
+)
+
+// HandleError registra as falhas dos jobs. Quando não há mais tentativas o asynq move a tarefa
+// para a fila de arquivados (dead-letter), onde pode ser inspecionada e reprocessada pelo /monitor.
+// O payload não é registrado, pois pode conter dados pessoais ou planilhas inteiras.
+func HandleError(ctx context.Context, task *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	queueName, _ := asynq.GetQueueName(ctx)
+
+	ctx = queue.LogContext(ctx, task)
+
+	if retried >= maxRetry || errors.Is(err, asynq.SkipRetry) {
+		slog.ErrorContext(ctx, "Job arquivado (dead-letter)", "queue", queueName, "attempts", retried+1, "error", err)
+		return
+	}
+
+	slog.WarnContext(ctx, "Job falhou", "queue", queueName, "attempt", retried+1, "max_attempts", maxRetry+1, "error", err)
+}
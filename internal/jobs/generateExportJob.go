@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/service/exportService"
+)
+
+// ExecuteGenerateExport gera o arquivo da exportação recebida no payload da tarefa
+// (ver exportService.ExportJob). Exportações removidas antes do processamento são ignoradas.
+func ExecuteGenerateExport(ctx context.Context, payload exportService.ExportPayload) error {
+	err := exportService.Process(ctx, payload.ExportID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	return err
+}
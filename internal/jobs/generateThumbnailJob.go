@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/service/attachmentService"
+)
+
+// ExecuteGenerateThumbnail gera as miniaturas do anexo recebido no payload da tarefa
+// (ver attachmentService.ThumbnailJob). Anexos removidos antes do processamento são ignorados.
+func ExecuteGenerateThumbnail(ctx context.Context, payload attachmentService.ThumbnailPayload) error {
+	err := attachmentService.GenerateThumbnails(ctx, payload.AttachmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/service/importService"
+)
+
+// ExecuteImportSpreadsheet processa a importação recebida no payload da tarefa
+// (ver importService.ImportJob). Importações removidas antes do processamento são ignoradas.
+func ExecuteImportSpreadsheet(ctx context.Context, payload importService.ImportPayload) error {
+	err := importService.Process(ctx, payload.ImportID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/queue"
+	"sixTask/config/tracing"
+)
+
+// Trace é o middleware do worker que abre um span para cada tarefa, continuando o trace da
+// requisição que a enfileirou (ver queue.StartProcessSpan)
+func Trace(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx, span := queue.StartProcessSpan(ctx, task)
+		err := next.ProcessTask(ctx, task)
+		tracing.End(span, err)
+
+		return err
+	})
+}
+
+// LogContext é o middleware do worker que inclui nos registros do job o request_id da
+// requisição que o enfileirou, além do tipo e do ID da tarefa (ver queue.LogContext)
+func LogContext(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return next.ProcessTask(queue.LogContext(ctx, task), task)
+	})
+}
+
+// TrackProgress é o middleware do worker que disponibiliza a tarefa no contexto dos jobs,
+// para que informem o andamento com queue.ReportProgress e queue.SetResult
+func TrackProgress(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return next.ProcessTask(queue.WithTask(ctx, task), task)
+	})
+}
+
+// RecordMetrics é o middleware do worker que registra a duração e o resultado de cada tarefa
+// nas métricas expostas em /metrics (ver queue.ObserveTask)
+func RecordMetrics(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		start := time.Now()
+		err := next.ProcessTask(ctx, task)
+		queue.ObserveTask(task, time.Since(start), err)
+
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/config/queue"
+	"sixTask/internal/service/accountService"
+	"sixTask/internal/service/attachmentService"
+	"sixTask/internal/service/exportService"
+	"sixTask/internal/service/importService"
+)
+
+// Registry retorna os jobs processados pelo worker. A definição de cada job (nome, payload,
+// fila e política de repetição) fica junto de quem o enfileira; aqui ela é associada ao handler.
+func Registry() *queue.Registry {
+	r := queue.NewRegistry()
+
+	// Job de exemplo disparado por /api/disparar-job
+	queue.Register(r, JobModel, Execute)
+
+	// Envio de email e dos emails de conta (redefinição de senha e confirmação de email)
+	queue.Register(r, emailprovider.SendMailJob, ExecuteSendEmail)
+	queue.Register(r, accountService.AccountMailJob, ExecuteSendAccountMail)
+
+	// Anexos: verificação antivírus, miniaturas e limpeza dos uploads em blocos abandonados
+	queue.Register(r, attachmentService.ScanJob, ExecuteScanAttachment)
+	queue.Register(r, attachmentService.ThumbnailJob, ExecuteGenerateThumbnail)
+	queue.Register(r, attachmentService.CleanupUploadsJob, ExecuteCleanupUploads)
+
+	// Planilhas (fila planilhas): importação e exportação
+	queue.Register(r, importService.ImportJob, ExecuteImportSpreadsheet)
+	queue.Register(r, exportService.ExportJob, ExecuteGenerateExport)
+
+	return r
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/service/attachmentService"
+)
+
+// ExecuteScanAttachment verifica o anexo recebido no payload da tarefa (ver attachmentService.ScanJob).
+// Falhas do scanner são repetidas; anexos removidos antes da verificação são ignorados.
+func ExecuteScanAttachment(ctx context.Context, payload attachmentService.ScanPayload) error {
+	err := attachmentService.Scan(ctx, payload.AttachmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/internal/service/accountService"
+)
+
+// ExecuteSendAccountMail gera o token e envia o email de conta recebido no payload da tarefa
+// (ver accountService.AccountMailJob). Usuários removidos antes do envio são ignorados.
+func ExecuteSendAccountMail(ctx context.Context, payload accountService.AccountMailPayload) error {
+	err := accountService.DeliverAccountMail(ctx, payload)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if errors.Is(err, emailprovider.ErrInvalidMessage) {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	return err
+}
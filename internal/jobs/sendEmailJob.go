@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+
+	emailprovider "sixTask/config/emailProvider"
+)
+
+// ExecuteSendEmail envia o email recebido no payload da tarefa (ver emailprovider.SendMailJob).
+// Erros de SMTP são repetidos com backoff; mensagens inválidas vão direto para a fila de arquivados.
+func ExecuteSendEmail(ctx context.Context, message emailprovider.EmailMessage) error {
+	err := emailprovider.SendMail(ctx, message)
+	if errors.Is(err, emailprovider.ErrInvalidMessage) {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	return err
+}
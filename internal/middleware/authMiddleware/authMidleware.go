@@ -1,14 +1,21 @@
 package authmiddleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 
+	"sixTask/config/logger"
 	authhelper "sixTask/helpers/authHelper"
+	"sixTask/internal/database"
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/policy"
+	"sixTask/internal/repository/refreshTokenRepository"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
@@ -18,7 +25,8 @@ func AuthMiddleware() gin.HandlerFunc {
 		bearer := c.GetHeader("Authorization")
 
 		if bearer == "" || !strings.HasPrefix(bearer, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token não fornecido"})
+			c.Error(apperror.Unauthorized("Token não fornecido"))
+			c.Abort()
 			return
 		}
 
@@ -35,16 +43,85 @@ func AuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token inválido: " + err.Error()})
+			c.Error(apperror.Unauthorized("Token inválido").WithCause(err))
+			c.Abort()
 			return
 		}
 
 		if !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token expirado"})
+			c.Error(apperror.Unauthorized("Token expirado"))
+			c.Abort()
+			return
+		}
+
+		// Tokens sem sessão não podem ser revogados e por isso não são aceitos
+		if claims.ID == "" {
+			c.Error(apperror.Unauthorized("Token inválido: sessão ausente"))
+			c.Abort()
+			return
+		}
+
+		// Verifica se a sessão do token não foi encerrada (logout ou reutilização de refresh token)
+		active, err := refreshTokenRepository.IsSessionActive(c.Request.Context(), claims.ID)
+		if err != nil {
+			c.Error(apperror.Unavailable("Não foi possível validar a sessão").WithCause(err))
+			c.Abort()
+			return
+		}
+
+		if !active {
+			c.Error(apperror.Unauthorized("Sessão revogada"))
+			c.Abort()
+			return
+		}
+
+		// Carrega o papel atual do usuário, usado pelas regras de autorização
+		role, err := findUserRole(c.Request.Context(), claims.UserID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.Error(apperror.Unauthorized("Usuário não encontrado"))
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.Error(apperror.Unavailable("Não foi possível validar a sessão").WithCause(err))
+			c.Abort()
 			return
 		}
 
 		c.Set("authUser", claims.UserID)
+		c.Set("authRole", role)
+		// Identifica o usuário nos registros feitos durante a requisição
+		c.Request = c.Request.WithContext(logger.With(c.Request.Context(), "user_id", claims.UserID))
+		c.Next()
+	}
+}
+
+// findUserRole busca o papel do usuário autenticado
+func findUserRole(ctx context.Context, userID int64) (string, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	user, err := queries.FindById(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Role, nil
+}
+
+// RequireAdmin permite a requisição apenas para administradores. Deve ser usado depois do AuthMiddleware.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := policy.RequireAdmin(policy.GetActor(c)); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
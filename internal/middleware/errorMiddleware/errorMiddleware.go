@@ -0,0 +1,26 @@
+package errormiddleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"sixTask/config/logger"
+	"sixTask/internal/http/apperror"
+)
+
+// Errors renderiza no formato apperror.Problem o último erro registrado com c.Error por um handler
+// ou middleware. Erros que não são AppError passam por apperror.From: registros inexistentes viram
+// 404, violações de restrições 409 ou 422 e o restante 500, sem expor a mensagem original, que fica
+// apenas no log da requisição e no trace.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		// Sem erros, ou com a resposta já enviada (ex.: falha no meio de um download), não há o que renderizar
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		appErr := apperror.From(c.Errors.Last().Err)
+		c.JSON(appErr.Status, appErr.Problem(logger.RequestID(c.Request.Context())))
+	}
+}
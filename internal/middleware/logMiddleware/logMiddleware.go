@@ -0,0 +1,97 @@
+package logmiddleware
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"sixTask/config/logger"
+	"sixTask/internal/http/apperror"
+)
+
+// RequestIDHeader é o cabeçalho que recebe e devolve o identificador da requisição
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limita os identificadores aceitos do cliente, evitando valores enormes ou com quebras de linha
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID reaproveita o X-Request-ID enviado pelo cliente (ou pelo proxy) ou gera um novo,
+// devolve-o na resposta e o guarda no contexto da requisição. Os registros feitos com
+// c.Request.Context() — em handlers, services, repositórios e jobs enfileirados — passam a incluí-lo,
+// assim como o span da requisição, quando há tracing.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = logger.NewRequestID()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Set("requestID", id)
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("request_id", id))
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// probePaths são as rotas consultadas periodicamente pelo orquestrador e pelo Prometheus
+var probePaths = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+// IsProbe informa se o caminho é de uma sonda (/healthz, /readyz ou /metrics)
+func IsProbe(path string) bool {
+	return probePaths[path]
+}
+
+// Logger registra cada requisição concluída no slog, no lugar do logger padrão do gin.
+// Respostas 5xx são registradas como erro e 4xx como aviso; as consultas bem-sucedidas
+// de /healthz, /readyz e /metrics ficam em debug para não encher o log.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case IsProbe(path):
+			level = slog.LevelDebug
+		}
+
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"ip", c.ClientIP(),
+			"bytes", c.Writer.Size(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		slog.Log(c.Request.Context(), level, "Requisição HTTP", attrs...)
+	}
+}
+
+// Recovery converte panics dos handlers em 500, registrando o erro com o request_id
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered any) {
+		ctx := c.Request.Context()
+		slog.ErrorContext(ctx, "Panic ao processar requisição", "panic", recovered, "path", c.Request.URL.Path, "stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, apperror.Internal("Erro interno do servidor", nil).Problem(logger.RequestID(ctx)))
+	})
+}
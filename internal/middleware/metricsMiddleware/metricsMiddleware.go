@@ -0,0 +1,35 @@
+package metricsmiddleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sixTask/config/metrics"
+)
+
+var requestDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "Duração das requisições HTTP por método, rota e status.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// Metrics registra a duração de cada requisição em /metrics. O label route é o padrão da
+// rota (ex.: /api/projects/:id), e não o caminho, para que a quantidade de séries não cresça
+// com os IDs; requisições sem rota correspondente são agrupadas em "unmatched".
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		requestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
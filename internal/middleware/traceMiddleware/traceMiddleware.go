@@ -0,0 +1,17 @@
+package tracemiddleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+
+	logmiddleware "sixTask/internal/middleware/logMiddleware"
+)
+
+// Trace cria um span para cada requisição, nomeado pela rota (ex.: /api/tasks/:id), que
+// continua o trace recebido no cabeçalho traceparent. Consultas, jobs enfileirados e emails
+// feitos com c.Request.Context() viram spans filhos. /healthz, /readyz e /metrics não geram spans.
+func Trace(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		return !logmiddleware.IsProbe(c.Request.URL.Path)
+	}))
+}
@@ -0,0 +1,39 @@
+package uploadmiddleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/http/apperror"
+)
+
+// MaxRequestSize retorna o tamanho máximo do corpo das rotas de upload
+// (UPLOAD_MAX_REQUEST_SIZE, padrão 110MB: o maior anexo permitido mais os campos do formulário)
+func MaxRequestSize() int64 {
+	return appConfig.Get().Upload.MaxRequestSize
+}
+
+// LimitBody interrompe a leitura de corpos maiores que maxBytes, antes que o
+// multipart seja gravado em disco. Requisições com Content-Length maior já são recusadas aqui.
+func LimitBody(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			c.Error(apperror.TooLarge("Requisição excede o tamanho máximo de " + storageProvider.FormatSize(maxBytes)))
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// IsBodyTooLarge informa se o erro foi causado pelo limite de LimitBody
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CanAccessAttachment permite ver o anexo a quem pode acessar o registro ao qual ele pertence
+func CanAccessAttachment(ctx context.Context, actor Actor, attachmentID int64) error {
+	attachment, err := findAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return CanAccessMorph(ctx, actor, attachment.AttachableType, attachment.AttachableID)
+}
+
+// CanModifyAttachment permite alterar ou remover o anexo a quem o enviou e a gerentes
+func CanModifyAttachment(ctx context.Context, actor Actor, attachmentID int64) error {
+	attachment, err := findAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return canModifyOwned(actor, attachment.UserID)
+}
+
+// CanCreateAttachment verifica se o usuário pode anexar arquivos ao registro informado em nome do usuário informado
+func CanCreateAttachment(ctx context.Context, actor Actor, userID pgtype.Int8, attachableType string, attachableID int64) error {
+	if err := canActAs(actor, userID); err != nil {
+		return err
+	}
+
+	return CanAccessMorph(ctx, actor, attachableType, attachableID)
+}
+
+// findAttachment busca o anexo usado na verificação de permissão
+func findAttachment(ctx context.Context, attachmentID int64) (database.Attachment, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	attachment, err := queries.FindAttachmentById(ctx, attachmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.Attachment{}, ErrNotFound
+	}
+
+	return attachment, err
+}
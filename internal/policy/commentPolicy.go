@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CanAccessComment permite ver o comentário a quem pode acessar o registro comentado
+func CanAccessComment(ctx context.Context, actor Actor, commentID int64) error {
+	comment, err := findComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	return CanAccessMorph(ctx, actor, comment.CommentableType, comment.CommentableID)
+}
+
+// CanModifyComment permite alterar ou remover o comentário ao autor e a gerentes
+func CanModifyComment(ctx context.Context, actor Actor, commentID int64) error {
+	comment, err := findComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	return canModifyOwned(actor, comment.UserID)
+}
+
+// CanCreateComment verifica se o usuário pode comentar no registro informado em nome do autor informado
+func CanCreateComment(ctx context.Context, actor Actor, userID pgtype.Int8, commentableType string, commentableID int64) error {
+	if err := canActAs(actor, userID); err != nil {
+		return err
+	}
+
+	return CanAccessMorph(ctx, actor, commentableType, commentableID)
+}
+
+// findComment busca o comentário usado na verificação de permissão
+func findComment(ctx context.Context, commentID int64) (database.Comment, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Comment{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	comment, err := queries.FindCommentById(ctx, commentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.Comment{}, ErrNotFound
+	}
+
+	return comment, err
+}
+
+// canModifyOwned permite a ação ao dono do registro e a gerentes
+func canModifyOwned(actor Actor, ownerID pgtype.Int8) error {
+	if actor.IsManager() || (ownerID.Valid && ownerID.Int64 == actor.UserID) {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// canActAs impede que usuários comuns criem registros em nome de outros usuários
+func canActAs(actor Actor, userID pgtype.Int8) error {
+	if actor.IsManager() || (userID.Valid && userID.Int64 == actor.UserID) {
+		return nil
+	}
+
+	return ErrForbidden
+}
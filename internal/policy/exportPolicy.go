@@ -0,0 +1,15 @@
+package policy
+
+import (
+	"sixTask/internal/database"
+)
+
+// CanAccessExport permite consultar e baixar a exportação apenas a quem a solicitou e a administradores,
+// já que o arquivo reflete o que o solicitante podia ver
+func CanAccessExport(actor Actor, export database.Export) error {
+	if actor.IsAdmin() || export.UserID == actor.UserID {
+		return nil
+	}
+
+	return ErrForbidden
+}
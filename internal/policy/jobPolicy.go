@@ -0,0 +1,11 @@
+package policy
+
+// CanAccessJob permite consultar um job a quem o disparou, a gerentes e a administradores.
+// Jobs sem solicitante (requestedBy zero) são internos e só podem ser consultados por gerentes.
+func CanAccessJob(actor Actor, requestedBy int64) error {
+	if requestedBy != 0 && requestedBy == actor.UserID {
+		return nil
+	}
+
+	return RequireManager(actor)
+}
@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"context"
+
+	"sixTask/internal/types/morphTypes"
+)
+
+// CanAccessMorph verifica o acesso ao registro de uma relação polimórfica (commentable, attachable e notifiable).
+// Tipos desconhecidos só podem ser acessados por gerentes.
+func CanAccessMorph(ctx context.Context, actor Actor, morphType string, id int64) error {
+	switch morphType {
+	case morphTypes.Project:
+		return CanAccessProject(ctx, actor, id)
+	case morphTypes.Task:
+		return CanAccessTask(ctx, actor, id)
+	case morphTypes.Subtask:
+		return CanAccessSubtask(ctx, actor, id)
+	default:
+		return RequireManager(actor)
+	}
+}
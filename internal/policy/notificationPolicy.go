@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/internal/database"
+)
+
+// CanAccessNotification permite acessar a notificação ao destinatário e a gerentes
+func CanAccessNotification(ctx context.Context, actor Actor, notificationID int64) error {
+	if actor.IsManager() {
+		return nil
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	notification, err := queries.FindNotificationById(ctx, notificationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return canModifyOwned(actor, notification.UserID)
+}
+
+// CanManageNotifications permite criar e alterar notificações apenas a gerentes
+func CanManageNotifications(actor Actor) error {
+	return RequireManager(actor)
+}
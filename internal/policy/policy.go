@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/http/apperror"
+	"sixTask/internal/types/roleTypes"
+)
+
+var (
+	// ErrForbidden indica que o usuário autenticado não pode executar a ação
+	ErrForbidden = apperror.Forbidden("Você não tem permissão para executar esta ação")
+
+	// ErrNotFound indica que o registro usado na verificação não existe
+	ErrNotFound = apperror.NotFound("Registro não encontrado")
+)
+
+// Actor representa o usuário autenticado que executa a requisição
+type Actor struct {
+	UserID int64
+	Role   string
+}
+
+// IsAdmin informa se o usuário é administrador
+func (a Actor) IsAdmin() bool {
+	return a.Role == roleTypes.Admin
+}
+
+// IsManager informa se o usuário é gerente ou administrador
+func (a Actor) IsManager() bool {
+	return a.Role == roleTypes.Admin || a.Role == roleTypes.Manager
+}
+
+// pgUserID retorna o ID do usuário no formato usado pelas colunas anuláveis
+func (a Actor) pgUserID() pgtype.Int8 {
+	return pgtype.Int8{Int64: a.UserID, Valid: true}
+}
+
+// GetActor retorna o usuário autenticado definido pelo AuthMiddleware
+func GetActor(c *gin.Context) Actor {
+	return Actor{
+		UserID: c.GetInt64("authUser"),
+		Role:   c.GetString("authRole"),
+	}
+}
+
+// RequireAdmin permite a ação apenas para administradores
+func RequireAdmin(actor Actor) error {
+	if !actor.IsAdmin() {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// RequireManager permite a ação apenas para gerentes e administradores
+func RequireManager(actor Actor) error {
+	if !actor.IsManager() {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// CanAccessUser permite acessar dados do próprio usuário; gerentes acessam dados de qualquer usuário
+func CanAccessUser(actor Actor, userID int64) error {
+	if actor.IsManager() || actor.UserID == userID {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// CanActAsUser permite alterar dados pertencentes ao próprio usuário; apenas administradores agem em nome de outros
+func CanActAsUser(actor Actor, userID int64) error {
+	if actor.IsAdmin() || actor.UserID == userID {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// allowIf converte o resultado de uma verificação de vínculo em erro
+func allowIf(allowed bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CanAccessProject permite acessar o projeto a gerentes e aos membros do projeto
+func CanAccessProject(ctx context.Context, actor Actor, projectID int64) error {
+	if actor.IsManager() {
+		return nil
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return allowIf(queries.IsProjectMember(ctx, database.IsProjectMemberParams{
+		ProjectID: pgtype.Int8{Int64: projectID, Valid: true},
+		UserID:    actor.pgUserID(),
+	}))
+}
+
+// CanManageProjects permite criar, alterar e remover projetos apenas a gerentes
+func CanManageProjects(actor Actor) error {
+	return RequireManager(actor)
+}
+
+// CanCreateInProject verifica se o usuário pode criar registros no projeto informado.
+// Registros sem projeto só podem ser criados por gerentes.
+func CanCreateInProject(ctx context.Context, actor Actor, projectID pgtype.Int8) error {
+	if !projectID.Valid {
+		return RequireManager(actor)
+	}
+
+	return CanAccessProject(ctx, actor, projectID.Int64)
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+// CanAccessTask permite acessar a tarefa a gerentes, membros do projeto e usuários vinculados à tarefa
+func CanAccessTask(ctx context.Context, actor Actor, taskID int64) error {
+	if actor.IsManager() {
+		return nil
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return allowIf(queries.IsTaskMember(ctx, database.IsTaskMemberParams{
+		TaskID: taskID,
+		UserID: actor.pgUserID(),
+	}))
+}
+
+// CanDeleteTask permite remover tarefas e subtarefas apenas a gerentes
+func CanDeleteTask(actor Actor) error {
+	return RequireManager(actor)
+}
+
+// CanCreateInTask verifica se o usuário pode criar subtarefas na tarefa informada.
+// Subtarefas sem tarefa só podem ser criadas por gerentes.
+func CanCreateInTask(ctx context.Context, actor Actor, taskID pgtype.Int8) error {
+	if !taskID.Valid {
+		return RequireManager(actor)
+	}
+
+	return CanAccessTask(ctx, actor, taskID.Int64)
+}
+
+// CanAccessSubtask permite acessar a subtarefa a quem pode acessar a tarefa e ao responsável pela subtarefa
+func CanAccessSubtask(ctx context.Context, actor Actor, subtaskID int64) error {
+	if actor.IsManager() {
+		return nil
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return allowIf(queries.IsSubtaskMember(ctx, database.IsSubtaskMemberParams{
+		SubtaskID: subtaskID,
+		UserID:    actor.pgUserID(),
+	}))
+}
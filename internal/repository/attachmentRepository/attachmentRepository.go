@@ -2,6 +2,9 @@ package attachmentRepository
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"sixTask/internal/database"
 )
@@ -22,8 +25,11 @@ type PaginationMeta struct {
 
 // GetAttachmentsWithPagination retorna os anexos paginados e os metadados de paginação
 func GetAttachmentsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -72,8 +78,11 @@ func GetAttachmentsWithPagination(ctx context.Context, page, limit int) (Paginat
 
 // GetAttachments retorna todos os anexos com paginação (mantido para compatibilidade)
 func GetAttachments(ctx context.Context, offset, limit int32) ([]database.Attachment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyAttachmentsWithPagination(ctx, database.FindManyAttachmentsWithPaginationParams{
@@ -84,8 +93,11 @@ func GetAttachments(ctx context.Context, offset, limit int32) ([]database.Attach
 
 // CountAttachments retorna o total de anexos (mantido para compatibilidade)
 func CountAttachments(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountAttachments(ctx)
@@ -93,8 +105,11 @@ func CountAttachments(ctx context.Context) (int64, error) {
 
 // GetAttachment retorna um anexo pelo ID
 func GetAttachment(ctx context.Context, id int64) (database.Attachment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindAttachmentById(ctx, id)
@@ -102,8 +117,11 @@ func GetAttachment(ctx context.Context, id int64) (database.Attachment, error) {
 
 // CreateAttachment cria um novo anexo
 func CreateAttachment(ctx context.Context, params database.CreateAttachmentParams) (database.Attachment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateAttachment(ctx, params)
@@ -111,8 +129,11 @@ func CreateAttachment(ctx context.Context, params database.CreateAttachmentParam
 
 // UpdateAttachment atualiza um anexo existente
 func UpdateAttachment(ctx context.Context, params database.UpdateAttachmentParams) (database.Attachment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateAttachment(ctx, params)
@@ -120,9 +141,119 @@ func UpdateAttachment(ctx context.Context, params database.UpdateAttachmentParam
 
 // DeleteAttachment remove um anexo
 func DeleteAttachment(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.DeleteAttachment(ctx, id)
 }
+
+// UpdateAttachmentScanResult registra o resultado da verificação antivírus do anexo
+func UpdateAttachmentScanResult(ctx context.Context, params database.UpdateAttachmentScanResultParams) (database.Attachment, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.UpdateAttachmentScanResult(ctx, params)
+}
+
+// StorageUsage é o uso de armazenamento, em bytes, do dono do anexo e do projeto do registro anexável.
+// ProjectID é inválido quando o registro anexável não pertence a um projeto (ou não existe).
+type StorageUsage struct {
+	User      int64
+	ProjectID pgtype.Int8
+	Project   int64
+}
+
+// GetStorageUsage retorna o uso atual de armazenamento do dono e do projeto do registro anexável
+func GetStorageUsage(ctx context.Context, userID pgtype.Int8, attachableType string, attachableID int64) (StorageUsage, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return storageUsage(ctx, queries, userID, attachableType, attachableID, false)
+}
+
+// CreateAttachmentWithinQuota cria o anexo na mesma transação em que lê o uso de armazenamento do dono
+// e do projeto. Locks consultivos por usuário e por projeto serializam os uploads concorrentes, de modo
+// que dois uploads não passem juntos pela verificação. check recebe o uso sem o novo anexo; se retornar
+// erro, o anexo não é criado.
+func CreateAttachmentWithinQuota(ctx context.Context, params database.CreateAttachmentParams, check func(StorageUsage) error) (database.Attachment, error) {
+	var attachment database.Attachment
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		usage, err := storageUsage(ctx, queries, params.UserID, params.AttachableType, params.AttachableID, true)
+		if err != nil {
+			return err
+		}
+		if err := check(usage); err != nil {
+			return err
+		}
+
+		attachment, err = queries.CreateAttachment(ctx, params)
+		return err
+	})
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+// storageUsage soma os anexos do usuário e do projeto. Com lock, bloqueia antes de cada soma o
+// lock consultivo correspondente até o fim da transação, sempre na ordem usuário e depois projeto.
+func storageUsage(ctx context.Context, queries *database.Queries, userID pgtype.Int8, attachableType string, attachableID int64, lock bool) (StorageUsage, error) {
+	var usage StorageUsage
+
+	if lock && userID.Valid {
+		if err := queries.LockStorageQuota(ctx, fmt.Sprintf("storage-quota:user:%d", userID.Int64)); err != nil {
+			return StorageUsage{}, err
+		}
+	}
+	used, err := queries.SumAttachmentSizeByUser(ctx, userID)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	usage.User = used
+
+	projectID, err := queries.FindAttachableProjectId(ctx, database.FindAttachableProjectIdParams{
+		AttachableType: attachableType,
+		AttachableID:   attachableID,
+	})
+	if err != nil || !projectID.Valid {
+		return usage, err
+	}
+	usage.ProjectID = projectID
+
+	if lock {
+		if err := queries.LockStorageQuota(ctx, fmt.Sprintf("storage-quota:project:%d", projectID.Int64)); err != nil {
+			return StorageUsage{}, err
+		}
+	}
+	usage.Project, err = queries.SumAttachmentSizeByProject(ctx, projectID.Int64)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+
+	return usage, nil
+}
+
+// MarkThumbnailGenerated registra que as miniaturas do anexo foram geradas
+func MarkThumbnailGenerated(ctx context.Context, id int64) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.UpdateAttachmentThumbnail(ctx, id)
+}
@@ -3,7 +3,10 @@ package clientRepository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"sixTask/internal/database"
+	"sixTask/internal/repository/projectRepository"
 )
 
 // PaginationResult contém os clientes paginados e os metadados de paginação
@@ -22,8 +25,11 @@ type PaginationMeta struct {
 
 // GetClientsWithPagination retorna os clientes paginados e os metadados de paginação
 func GetClientsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -72,8 +78,11 @@ func GetClientsWithPagination(ctx context.Context, page, limit int) (PaginationR
 
 // GetClients retorna todos os clientes com paginação (mantido para compatibilidade)
 func GetClients(ctx context.Context, offset, limit int32) ([]database.Client, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyClientsWithPagination(ctx, database.FindManyClientsWithPaginationParams{
@@ -84,8 +93,11 @@ func GetClients(ctx context.Context, offset, limit int32) ([]database.Client, er
 
 // CountClients retorna o total de clientes (mantido para compatibilidade)
 func CountClients(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountClients(ctx)
@@ -93,8 +105,11 @@ func CountClients(ctx context.Context) (int64, error) {
 
 // GetClient retorna um cliente pelo ID
 func GetClient(ctx context.Context, id int64) (database.Client, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Client{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindClientById(ctx, id)
@@ -102,8 +117,11 @@ func GetClient(ctx context.Context, id int64) (database.Client, error) {
 
 // CreateClient cria um novo cliente
 func CreateClient(ctx context.Context, params database.CreateClientParams) (database.Client, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Client{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateClient(ctx, params)
@@ -111,18 +129,36 @@ func CreateClient(ctx context.Context, params database.CreateClientParams) (data
 
 // UpdateClient atualiza um cliente existente
 func UpdateClient(ctx context.Context, params database.UpdateClientParams) (database.Client, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Client{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateClient(ctx, params)
 }
 
-// DeleteClient remove um cliente
-func DeleteClient(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// DeleteClient remove um cliente junto com os projetos e registros relacionados.
+// Retorna os anexos removidos, cujos arquivos ainda estão no storage.
+func DeleteClient(ctx context.Context, id int64) ([]database.Attachment, error) {
+	var attachments []database.Attachment
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		projectIds, err := queries.FindProjectIdsByClientId(ctx, pgtype.Int8{Int64: id, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		attachments, err = projectRepository.DeleteProjectsRelations(ctx, queries, projectIds)
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteClient(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	queries := database.New(conn)
-	return queries.DeleteClient(ctx, id)
+	return attachments, nil
 }
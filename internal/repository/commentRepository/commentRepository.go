@@ -22,8 +22,11 @@ type PaginationMeta struct {
 
 // GetCommentsWithPagination retorna os comentários paginados e os metadados de paginação
 func GetCommentsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -72,8 +75,11 @@ func GetCommentsWithPagination(ctx context.Context, page, limit int) (Pagination
 
 // GetComments retorna todos os comentários com paginação (mantido para compatibilidade)
 func GetComments(ctx context.Context, offset, limit int32) ([]database.Comment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyCommentsWithPagination(ctx, database.FindManyCommentsWithPaginationParams{
@@ -84,8 +90,11 @@ func GetComments(ctx context.Context, offset, limit int32) ([]database.Comment,
 
 // CountComments retorna o total de comentários (mantido para compatibilidade)
 func CountComments(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountComments(ctx)
@@ -93,8 +102,11 @@ func CountComments(ctx context.Context) (int64, error) {
 
 // GetComment retorna um comentário pelo ID
 func GetComment(ctx context.Context, id int64) (database.Comment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Comment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindCommentById(ctx, id)
@@ -102,8 +114,11 @@ func GetComment(ctx context.Context, id int64) (database.Comment, error) {
 
 // CreateComment cria um novo comentário
 func CreateComment(ctx context.Context, params database.CreateCommentParams) (database.Comment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Comment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateComment(ctx, params)
@@ -111,8 +126,11 @@ func CreateComment(ctx context.Context, params database.CreateCommentParams) (da
 
 // UpdateComment atualiza um comentário existente
 func UpdateComment(ctx context.Context, params database.UpdateCommentParams) (database.Comment, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Comment{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateComment(ctx, params)
@@ -120,8 +138,11 @@ func UpdateComment(ctx context.Context, params database.UpdateCommentParams) (da
 
 // DeleteComment remove um comentário
 func DeleteComment(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.DeleteComment(ctx, id)
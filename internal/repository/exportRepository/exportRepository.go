@@ -0,0 +1,124 @@
+package exportRepository
+
+import (
+	"context"
+
+	"sixTask/internal/database"
+	"sixTask/internal/entity/exportEntity"
+)
+
+// PaginationResult contém as exportações paginadas e os metadados de paginação
+type PaginationResult struct {
+	Data []exportEntity.Export `json:"data"`
+	Meta PaginationMeta        `json:"meta"`
+}
+
+// PaginationMeta contém os metadados de paginação
+type PaginationMeta struct {
+	CurrentPage int   `json:"current_page"`
+	PerPage     int   `json:"per_page"`
+	Total       int64 `json:"total"`
+	TotalPages  int   `json:"total_pages"`
+}
+
+// GetExportsByUserWithPagination retorna as exportações do usuário, das mais recentes para as mais antigas
+func GetExportsByUserWithPagination(ctx context.Context, userID int64, page, limit int) (PaginationResult, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+
+	// Validação dos parâmetros
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	// Cálculo do offset
+	offset := (page - 1) * limit
+
+	// Buscar o total de exportações para metadados de paginação
+	total, err := queries.CountExportsByUser(ctx, userID)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Calcular o total de páginas
+	totalPages := (int(total) + limit - 1) / limit
+
+	// Buscar exportações com paginação via SQL
+	paginatedExports, err := queries.FindExportsByUserWithPagination(ctx, database.FindExportsByUserWithPaginationParams{
+		UserID: userID,
+		Offset: int32(offset),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Montar resultado com metadados de paginação
+	result := PaginationResult{
+		Data: exportEntity.FromDatabaseExports(paginatedExports),
+		Meta: PaginationMeta{
+			CurrentPage: page,
+			PerPage:     limit,
+			Total:       total,
+			TotalPages:  totalPages,
+		},
+	}
+
+	return result, nil
+}
+
+// GetExport retorna uma exportação pelo ID
+func GetExport(ctx context.Context, id int64) (database.Export, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Export{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FindExportById(ctx, id)
+}
+
+// CreateExport registra uma nova exportação pendente
+func CreateExport(ctx context.Context, params database.CreateExportParams) (database.Export, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Export{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.CreateExport(ctx, params)
+}
+
+// StartExport marca a exportação como em processamento
+func StartExport(ctx context.Context, id int64) (database.Export, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Export{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.StartExport(ctx, id)
+}
+
+// FinishExport grava a situação final e o arquivo gerado pela exportação
+func FinishExport(ctx context.Context, params database.FinishExportParams) (database.Export, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Export{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FinishExport(ctx, params)
+}
@@ -0,0 +1,135 @@
+package importRepository
+
+import (
+	"context"
+
+	"sixTask/internal/database"
+)
+
+// PaginationResult contém as importações paginadas e os metadados de paginação
+type PaginationResult struct {
+	Data []database.Import `json:"data"`
+	Meta PaginationMeta    `json:"meta"`
+}
+
+// PaginationMeta contém os metadados de paginação
+type PaginationMeta struct {
+	CurrentPage int   `json:"current_page"`
+	PerPage     int   `json:"per_page"`
+	Total       int64 `json:"total"`
+	TotalPages  int   `json:"total_pages"`
+}
+
+// GetImportsWithPagination retorna as importações, das mais recentes para as mais antigas
+func GetImportsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+
+	// Validação dos parâmetros
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	// Cálculo do offset
+	offset := (page - 1) * limit
+
+	// Buscar o total de importações para metadados de paginação
+	total, err := queries.CountImports(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Calcular o total de páginas
+	totalPages := (int(total) + limit - 1) / limit
+
+	// Buscar importações com paginação via SQL
+	paginatedImports, err := queries.FindImportsWithPagination(ctx, database.FindImportsWithPaginationParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Montar resultado com metadados de paginação
+	result := PaginationResult{
+		Data: paginatedImports,
+		Meta: PaginationMeta{
+			CurrentPage: page,
+			PerPage:     limit,
+			Total:       total,
+			TotalPages:  totalPages,
+		},
+	}
+
+	return result, nil
+}
+
+// GetImport retorna uma importação pelo ID
+func GetImport(ctx context.Context, id int64) (database.Import, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Import{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FindImportById(ctx, id)
+}
+
+// CreateImport registra uma nova importação pendente
+func CreateImport(ctx context.Context, params database.CreateImportParams) (database.Import, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Import{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.CreateImport(ctx, params)
+}
+
+// StartImport marca a importação como em processamento e zera os contadores,
+// de modo que uma nova tentativa do worker recomece a contagem
+func StartImport(ctx context.Context, id int64) (database.Import, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Import{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.StartImport(ctx, id)
+}
+
+// UpdateImportProgress grava os contadores parciais da importação
+func UpdateImportProgress(ctx context.Context, params database.UpdateImportProgressParams) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.UpdateImportProgress(ctx, params)
+}
+
+// FinishImport grava a situação final, os contadores e o relatório de erros da importação
+func FinishImport(ctx context.Context, params database.FinishImportParams) (database.Import, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Import{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FinishImport(ctx, params)
+}
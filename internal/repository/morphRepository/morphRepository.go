@@ -0,0 +1,42 @@
+package morphRepository
+
+import (
+	"context"
+
+	"sixTask/internal/database"
+)
+
+// DeleteMorphRelations remove comentários, anexos e notificações ligados aos registros informados
+// e retorna os anexos removidos, cujos arquivos devem ser apagados do storage depois do commit.
+// Deve ser chamado com as queries de uma transação para que a remoção seja atômica.
+func DeleteMorphRelations(ctx context.Context, queries *database.Queries, morphType string, ids []int64) ([]database.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	err := queries.DeleteCommentsByCommentable(ctx, database.DeleteCommentsByCommentableParams{
+		CommentableType: morphType,
+		CommentableIds:  ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := queries.DeleteAttachmentsByAttachable(ctx, database.DeleteAttachmentsByAttachableParams{
+		AttachableType: morphType,
+		AttachableIds:  ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queries.DeleteNotificationsByNotifiable(ctx, database.DeleteNotificationsByNotifiableParams{
+		NotifiableType: morphType,
+		NotifiableIds:  ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
@@ -22,8 +22,11 @@ type PaginationMeta struct {
 
 // GetNotificationsWithPagination retorna as notificações paginadas e os metadados de paginação
 func GetNotificationsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -72,8 +75,11 @@ func GetNotificationsWithPagination(ctx context.Context, page, limit int) (Pagin
 
 // GetNotifications retorna todas as notificações com paginação (mantido para compatibilidade)
 func GetNotifications(ctx context.Context, offset, limit int32) ([]database.Notification, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyNotificationsWithPagination(ctx, database.FindManyNotificationsWithPaginationParams{
@@ -84,8 +90,11 @@ func GetNotifications(ctx context.Context, offset, limit int32) ([]database.Noti
 
 // CountNotifications retorna o total de notificações (mantido para compatibilidade)
 func CountNotifications(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountNotifications(ctx)
@@ -93,8 +102,11 @@ func CountNotifications(ctx context.Context) (int64, error) {
 
 // GetNotification retorna uma notificação pelo ID
 func GetNotification(ctx context.Context, id int64) (database.Notification, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Notification{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindNotificationById(ctx, id)
@@ -102,8 +114,11 @@ func GetNotification(ctx context.Context, id int64) (database.Notification, erro
 
 // CreateNotification cria uma nova notificação
 func CreateNotification(ctx context.Context, params database.CreateNotificationParams) (database.Notification, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Notification{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateNotification(ctx, params)
@@ -111,8 +126,11 @@ func CreateNotification(ctx context.Context, params database.CreateNotificationP
 
 // UpdateNotification atualiza uma notificação existente
 func UpdateNotification(ctx context.Context, params database.UpdateNotificationParams) (database.Notification, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Notification{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateNotification(ctx, params)
@@ -120,8 +138,11 @@ func UpdateNotification(ctx context.Context, params database.UpdateNotificationP
 
 // DeleteNotification remove uma notificação
 func DeleteNotification(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.DeleteNotification(ctx, id)
@@ -5,6 +5,9 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"sixTask/helpers/conversionTypes"
 	"sixTask/internal/http/request/projectRequest"
+	"sixTask/internal/repository/morphRepository"
+	"sixTask/internal/repository/taskRepository"
+	"sixTask/internal/types/morphTypes"
 
 	"sixTask/internal/database"
 )
@@ -25,8 +28,11 @@ type PaginationMeta struct {
 
 // GetProjectsWithPagination retorna os projetos paginados e os metadados de paginação
 func GetProjectsWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -75,8 +81,11 @@ func GetProjectsWithPagination(ctx context.Context, page, limit int) (Pagination
 
 // GetProjects retorna todos os projetos com paginação (mantido para compatibilidade)
 func GetProjects(ctx context.Context, offset, limit int32) ([]database.Project, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyProjectsWithPagination(ctx, database.FindManyProjectsWithPaginationParams{
@@ -87,8 +96,11 @@ func GetProjects(ctx context.Context, offset, limit int32) ([]database.Project,
 
 // CountProjects retorna o total de projetos (mantido para compatibilidade)
 func CountProjects(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountProjects(ctx)
@@ -96,117 +108,177 @@ func CountProjects(ctx context.Context) (int64, error) {
 
 // GetProject retorna um projeto pelo ID
 func GetProject(ctx context.Context, id int64) (database.Project, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Project{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindProjectById(ctx, id)
 }
 
-// CreateProject cria um novo projeto
-func CreateProject(request projectRequest.CreateProjectRequest) (database.Project, []database.User, error) {
+// CreateProject cria um novo projeto e suas relações com usuários em uma única transação
+func CreateProject(ctx context.Context, request projectRequest.CreateProjectRequest) (database.Project, []database.User, error) {
 	params := request.ToCreateProjectParams().(database.CreateProjectParams)
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
 
-	queries := database.New(conn)
-	project, err := queries.CreateProject(ctx, params)
+	var project database.Project
+	var users []database.User
 
-	if err != nil {
-		return database.Project{}, []database.User{}, err
-	}
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
 
-	for _, user_id := range request.UsersId {
+		project, err = queries.CreateProject(ctx, params)
+		if err != nil {
+			return err
+		}
 
-		queries.CreateUserProject(ctx, database.CreateUserProjectParams{
-			UserID: user_id,
-			ProjectID: pgtype.Int8{
-				Int64: project.ID,
-				Valid: true,
-			},
-		})
+		if err := createUserProjects(ctx, queries, project.ID, request.UsersId); err != nil {
+			return err
+		}
 
+		usersList := conversionTypes.ConvertPgInt8Slice(request.UsersId)
+		users, err = queries.FindManyUserIds(ctx, usersList)
+		return err
+	})
+	if err != nil {
+		return database.Project{}, []database.User{}, err
 	}
 
-	usersList := conversionTypes.ConvertPgInt8Slice(request.UsersId)
-
-	users, err := queries.FindManyUserIds(ctx, usersList)
-
 	return project, users, nil
 }
 
 // UpdateProject atualiza um projeto existente
 func UpdateProject(ctx context.Context, params database.UpdateProjectParams) (database.Project, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Project{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateProject(ctx, params)
 }
 
-// UpdateProjectWithUsers atualiza um projeto existente e suas relações com usuários
-func UpdateProjectWithUsers(request projectRequest.UpdateProjectRequest, id int64) (database.Project, []database.User, error) {
+// UpdateProjectWithUsers atualiza um projeto existente e suas relações com usuários em uma única transação
+func UpdateProjectWithUsers(ctx context.Context, request projectRequest.UpdateProjectRequest, id int64) (database.Project, []database.User, error) {
 	// Converter a request para os parâmetros do projeto
 	params := request.ToUpdateProjectParams(id).(database.UpdateProjectParams)
 
-	// Conectar ao banco de dados
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	var project database.Project
+	var users []database.User
 
-	queries := database.New(conn)
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
 
-	// Atualizar o projeto
-	project, err := queries.UpdateProject(ctx, params)
-	if err != nil {
-		return database.Project{}, []database.User{}, err
-	}
+		// Atualizar o projeto
+		project, err = queries.UpdateProject(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		// Excluir todas as relações existentes entre usuários e o projeto
+		err = queries.DeleteUserProjectsByProjectId(ctx, pgtype.Int8{
+			Int64: id,
+			Valid: true,
+		})
+		if err != nil {
+			return err
+		}
 
-	// Excluir todas as relações existentes entre usuários e o projeto
-	err = queries.DeleteUserProjectsByProjectId(ctx, pgtype.Int8{
-		Int64: id,
-		Valid: true,
+		// Criar novas relações entre usuários e o projeto
+		if err := createUserProjects(ctx, queries, id, request.UsersId); err != nil {
+			return err
+		}
+
+		// Buscar os usuários para retornar junto com o projeto
+		usersList := conversionTypes.ConvertPgInt8Slice(request.UsersId)
+		users, err = queries.FindManyUserIds(ctx, usersList)
+		return err
 	})
 	if err != nil {
 		return database.Project{}, []database.User{}, err
 	}
 
-	// Criar novas relações entre usuários e o projeto
-	for _, user_id := range request.UsersId {
-		err = queries.CreateUserProject(ctx, database.CreateUserProjectParams{
-			UserID: user_id,
-			ProjectID: pgtype.Int8{
-				Int64: id,
-				Valid: true,
-			},
-		})
+	return project, users, nil
+}
+
+// DeleteProject remove um projeto junto com tarefas, pivôs, comentários, anexos e notificações relacionados.
+// Retorna os anexos removidos, cujos arquivos ainda estão no storage.
+func DeleteProject(ctx context.Context, id int64) ([]database.Attachment, error) {
+	var attachments []database.Attachment
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
+		attachments, err = DeleteProjectsRelations(ctx, queries, []int64{id})
 		if err != nil {
-			return database.Project{}, []database.User{}, err
+			return err
 		}
+
+		return queries.DeleteProject(ctx, id)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Buscar os usuários para retornar junto com o projeto
-	usersList := conversionTypes.ConvertPgInt8Slice(request.UsersId)
-	users, err := queries.FindManyUserIds(ctx, usersList)
+	return attachments, nil
+}
+
+// DeleteProjectsRelations remove os registros que não são apagados em cascata pelas foreign keys
+// e retorna os anexos removidos. Deve ser chamado com as queries de uma transação.
+func DeleteProjectsRelations(ctx context.Context, queries *database.Queries, projectIds []int64) ([]database.Attachment, error) {
+	taskIds, err := queries.FindTaskIdsByProjectIds(ctx, projectIds)
 	if err != nil {
-		return database.Project{}, []database.User{}, err
+		return nil, err
 	}
 
-	return project, users, nil
+	taskAttachments, err := taskRepository.DeleteTasksRelations(ctx, queries, taskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	projectAttachments, err := morphRepository.DeleteMorphRelations(ctx, queries, morphTypes.Project, projectIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectId := range projectIds {
+		err := queries.DeleteUserProjectsByProjectId(ctx, pgtype.Int8{
+			Int64: projectId,
+			Valid: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(taskAttachments, projectAttachments...), nil
 }
 
-// DeleteProject remove um projeto
-func DeleteProject(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// createUserProjects cria as relações entre o projeto e os usuários informados
+func createUserProjects(ctx context.Context, queries *database.Queries, projectId int64, usersId []pgtype.Int8) error {
+	for _, userId := range usersId {
+		err := queries.CreateUserProject(ctx, database.CreateUserProjectParams{
+			UserID: userId,
+			ProjectID: pgtype.Int8{
+				Int64: projectId,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	queries := database.New(conn)
-	return queries.DeleteProject(ctx, id)
+	return nil
 }
 
 // GetProjectsWithUsersAndPagination retorna os projetos com usuários e paginação
-func GetProjectsWithUsersAndPagination(page, limit int) ([]database.FindManyProjectsWithUsersWithPaginationRow, int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+func GetProjectsWithUsersAndPagination(ctx context.Context, page, limit int) ([]database.FindManyProjectsWithUsersWithPaginationRow, int64, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -240,18 +312,24 @@ func GetProjectsWithUsersAndPagination(page, limit int) ([]database.FindManyProj
 }
 
 // FindManyProjectsWithUsers retorna todos os projetos com usuários
-func FindManyProjectsWithUsers() ([]database.FindManyProjectsWithUsersRow, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+func FindManyProjectsWithUsers(ctx context.Context) ([]database.FindManyProjectsWithUsersRow, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyProjectsWithUsers(ctx)
 }
 
 // GetProjectsByClientIdAndPagination retorna projetos pelo ID do cliente com paginação
-func GetProjectsByClientIdAndPagination(clientId pgtype.Int8, page, limit int) ([]database.FindManyProjectsClientWithUsersWithPaginationRow, int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+func GetProjectsByClientIdAndPagination(ctx context.Context, clientId pgtype.Int8, page, limit int) ([]database.FindManyProjectsClientWithUsersWithPaginationRow, int64, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -286,9 +364,12 @@ func GetProjectsByClientIdAndPagination(clientId pgtype.Int8, page, limit int) (
 }
 
 // GetProjectsByUserIdAndPagination retorna projetos pelo ID do usuário com paginação
-func GetProjectsByUserIdAndPagination(userId pgtype.Int8, page, limit int) ([]database.FindManyProjectsUserWithUsersWithPaginationRow, int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+func GetProjectsByUserIdAndPagination(ctx context.Context, userId pgtype.Int8, page, limit int) ([]database.FindManyProjectsUserWithUsersWithPaginationRow, int64, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
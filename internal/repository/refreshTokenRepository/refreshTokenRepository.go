@@ -0,0 +1,173 @@
+package refreshTokenRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	authhelper "sixTask/helpers/authHelper"
+	"sixTask/internal/database"
+)
+
+var (
+	// ErrInvalidRefreshToken indica um refresh token inexistente ou expirado
+	ErrInvalidRefreshToken = errors.New("refresh token inválido ou expirado")
+
+	// ErrRefreshTokenReused indica que um refresh token já rotacionado foi reutilizado.
+	// Quando isso acontece toda a sessão é revogada.
+	ErrRefreshTokenReused = errors.New("refresh token reutilizado, sessão revogada")
+)
+
+// Session contém o par de tokens emitido para uma sessão
+type Session struct {
+	SessionID    string
+	UserID       int64
+	RefreshToken string
+}
+
+// CreateSession inicia uma nova sessão para o usuário e retorna o primeiro refresh token
+func CreateSession(ctx context.Context, userID int64) (Session, error) {
+	sessionID, err := authhelper.NewSessionID()
+	if err != nil {
+		return Session{}, err
+	}
+
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return Session{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+
+	token, err := createRefreshToken(ctx, queries, userID, sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	return Session{SessionID: sessionID, UserID: userID, RefreshToken: token.raw}, nil
+}
+
+// Rotate troca um refresh token válido por um novo da mesma sessão.
+// Se o token já tiver sido usado, toda a sessão é revogada e ErrRefreshTokenReused é retornado.
+func Rotate(ctx context.Context, rawToken string) (Session, error) {
+	var session Session
+	reused := false
+
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		current, err := queries.FindRefreshTokenByHashForUpdate(ctx, authhelper.HashToken(rawToken))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+
+		// Token já rotacionado ou revogado: revoga a família inteira e confirma a transação
+		if current.RevokedAt.Valid {
+			reused = true
+			return queries.RevokeRefreshTokenFamily(ctx, current.FamilyID)
+		}
+
+		if !current.ExpiresAt.Time.After(time.Now().UTC()) {
+			return ErrInvalidRefreshToken
+		}
+
+		next, err := createRefreshToken(ctx, queries, current.UserID, current.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		err = queries.RevokeRefreshToken(ctx, database.RevokeRefreshTokenParams{
+			ReplacedBy: pgtype.Int8{Int64: next.ID, Valid: true},
+			ID:         current.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		session = Session{SessionID: current.FamilyID, UserID: current.UserID, RefreshToken: next.raw}
+		return nil
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	if reused {
+		return Session{}, ErrRefreshTokenReused
+	}
+
+	return session, nil
+}
+
+// RevokeSession revoga a sessão à qual o refresh token pertence.
+// Tokens desconhecidos são ignorados para que o logout seja idempotente.
+func RevokeSession(ctx context.Context, rawToken string) error {
+	return database.RunInTx(ctx, func(queries *database.Queries) error {
+		current, err := queries.FindRefreshTokenByHashForUpdate(ctx, authhelper.HashToken(rawToken))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return queries.RevokeRefreshTokenFamily(ctx, current.FamilyID)
+	})
+}
+
+// RevokeUserSessions revoga todas as sessões ativas do usuário
+func RevokeUserSessions(ctx context.Context, userID int64) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.RevokeUserRefreshTokens(ctx, userID)
+}
+
+// IsSessionActive informa se a sessão ainda possui um refresh token válido e não revogado
+func IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.IsRefreshTokenFamilyActive(ctx, database.IsRefreshTokenFamilyActiveParams{
+		FamilyID: sessionID,
+		Now:      pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+// issuedToken guarda o registro persistido junto com o valor do token que só é conhecido na emissão
+type issuedToken struct {
+	database.RefreshToken
+	raw string
+}
+
+// createRefreshToken gera e persiste um novo refresh token para a sessão
+func createRefreshToken(ctx context.Context, queries *database.Queries, userID int64, sessionID string) (issuedToken, error) {
+	raw, hash, err := authhelper.GenerateRandomToken()
+	if err != nil {
+		return issuedToken{}, err
+	}
+
+	token, err := queries.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		UserID:    userID,
+		TokenHash: hash,
+		FamilyID:  sessionID,
+		ExpiresAt: pgtype.Timestamp{Time: time.Now().UTC().Add(authhelper.GetRefreshTokenTTL()), Valid: true},
+	})
+	if err != nil {
+		return issuedToken{}, err
+	}
+
+	return issuedToken{RefreshToken: token, raw: raw}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"sixTask/internal/database"
+	"sixTask/internal/repository/morphRepository"
+	"sixTask/internal/types/morphTypes"
 )
 
 // PaginationResult contém as subtarefas paginadas e os metadados de paginação
@@ -22,8 +24,11 @@ type PaginationMeta struct {
 
 // GetSubtasksWithPagination retorna as subtarefas paginadas e os metadados de paginação
 func GetSubtasksWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -72,8 +77,11 @@ func GetSubtasksWithPagination(ctx context.Context, page, limit int) (Pagination
 
 // GetSubtasks retorna todas as subtarefas com paginação (mantido para compatibilidade)
 func GetSubtasks(ctx context.Context, offset, limit int32) ([]database.Subtask, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManySubtasksWithPagination(ctx, database.FindManySubtasksWithPaginationParams{
@@ -84,8 +92,11 @@ func GetSubtasks(ctx context.Context, offset, limit int32) ([]database.Subtask,
 
 // CountSubtasks retorna o total de subtarefas (mantido para compatibilidade)
 func CountSubtasks(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountSubtasks(ctx)
@@ -93,8 +104,11 @@ func CountSubtasks(ctx context.Context) (int64, error) {
 
 // GetSubtask retorna uma subtarefa pelo ID
 func GetSubtask(ctx context.Context, id int64) (database.Subtask, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Subtask{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindSubtaskById(ctx, id)
@@ -102,8 +116,11 @@ func GetSubtask(ctx context.Context, id int64) (database.Subtask, error) {
 
 // CreateSubtask cria uma nova subtarefa
 func CreateSubtask(ctx context.Context, params database.CreateSubtaskParams) (database.Subtask, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Subtask{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateSubtask(ctx, params)
@@ -111,18 +128,32 @@ func CreateSubtask(ctx context.Context, params database.CreateSubtaskParams) (da
 
 // UpdateSubtask atualiza uma subtarefa existente
 func UpdateSubtask(ctx context.Context, params database.UpdateSubtaskParams) (database.Subtask, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Subtask{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateSubtask(ctx, params)
 }
 
-// DeleteSubtask remove uma subtarefa
-func DeleteSubtask(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// DeleteSubtask remove uma subtarefa junto com comentários, anexos e notificações relacionados.
+// Retorna os anexos removidos, cujos arquivos ainda estão no storage.
+func DeleteSubtask(ctx context.Context, id int64) ([]database.Attachment, error) {
+	var attachments []database.Attachment
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
+		attachments, err = morphRepository.DeleteMorphRelations(ctx, queries, morphTypes.Subtask, []int64{id})
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteSubtask(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	queries := database.New(conn)
-	return queries.DeleteSubtask(ctx, id)
+	return attachments, nil
 }
@@ -3,8 +3,14 @@ package taskRepository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/helpers/conversionTypes"
 	"sixTask/internal/database"
 	"sixTask/internal/entity/taskEntity"
+	"sixTask/internal/http/request/taskRequest"
+	"sixTask/internal/repository/morphRepository"
+	"sixTask/internal/types/morphTypes"
 )
 
 // PaginationResult contém as tarefas paginadas e os metadados de paginação
@@ -23,8 +29,11 @@ type PaginationMeta struct {
 
 // GetTasksWithPagination retorna as tarefas paginadas e os metadados de paginação
 func GetTasksWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -73,8 +82,11 @@ func GetTasksWithPagination(ctx context.Context, page, limit int) (PaginationRes
 
 // GetTasksWithPaginationAndUsers retorna as tarefas paginadas com informações de usuário
 func GetTasksWithPaginationAndUsers(ctx context.Context, page, limit int) (taskEntity.TaskWithPagination, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return taskEntity.TaskWithPagination{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -151,8 +163,11 @@ func GetTasksWithPaginationAndUsers(ctx context.Context, page, limit int) (taskE
 
 // GetTasks retorna todas as tarefas com paginação (mantido para compatibilidade)
 func GetTasks(ctx context.Context, offset, limit int32) ([]database.Task, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyTasksWithPagination(ctx, database.FindManyTasksWithPaginationParams{
@@ -163,8 +178,11 @@ func GetTasks(ctx context.Context, offset, limit int32) ([]database.Task, error)
 
 // CountTasks retorna o total de tarefas (mantido para compatibilidade)
 func CountTasks(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountTasks(ctx)
@@ -172,8 +190,11 @@ func CountTasks(ctx context.Context) (int64, error) {
 
 // GetTask retorna uma tarefa pelo ID
 func GetTask(ctx context.Context, id int64) (database.Task, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Task{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindTaskById(ctx, id)
@@ -181,8 +202,11 @@ func GetTask(ctx context.Context, id int64) (database.Task, error) {
 
 // CreateTask cria uma nova tarefa
 func CreateTask(ctx context.Context, params database.CreateTaskParams) (database.Task, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Task{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CreateTask(ctx, params)
@@ -190,18 +214,142 @@ func CreateTask(ctx context.Context, params database.CreateTaskParams) (database
 
 // UpdateTask atualiza uma tarefa existente
 func UpdateTask(ctx context.Context, params database.UpdateTaskParams) (database.Task, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Task{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.UpdateTask(ctx, params)
 }
 
-// DeleteTask remove uma tarefa
-func DeleteTask(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// CreateTaskWithUsers cria uma nova tarefa e suas relações com usuários em uma única transação
+func CreateTaskWithUsers(ctx context.Context, request taskRequest.CreateTaskRequest) (database.Task, []database.User, error) {
+	params := request.ToCreateTaskParams().(database.CreateTaskParams)
 
-	queries := database.New(conn)
-	return queries.DeleteTask(ctx, id)
+	var task database.Task
+	var users []database.User
+
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
+
+		task, err = queries.CreateTask(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if err := createUserTasks(ctx, queries, task.ID, request.UsersId); err != nil {
+			return err
+		}
+
+		users, err = queries.FindManyUserIds(ctx, conversionTypes.ConvertPgInt8Slice(request.UsersId))
+		return err
+	})
+	if err != nil {
+		return database.Task{}, []database.User{}, err
+	}
+
+	return task, users, nil
+}
+
+// UpdateTaskWithUsers atualiza uma tarefa existente e suas relações com usuários em uma única transação
+func UpdateTaskWithUsers(ctx context.Context, request taskRequest.UpdateTaskRequest, id int64) (database.Task, []database.User, error) {
+	params := request.ToUpdateTaskParams(id).(database.UpdateTaskParams)
+
+	var task database.Task
+	var users []database.User
+
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
+
+		task, err = queries.UpdateTask(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		// Substituir as relações existentes entre usuários e a tarefa
+		if err := queries.DeleteUserTasksByTaskIds(ctx, []int64{id}); err != nil {
+			return err
+		}
+
+		if err := createUserTasks(ctx, queries, id, request.UsersId); err != nil {
+			return err
+		}
+
+		users, err = queries.FindManyUserIds(ctx, conversionTypes.ConvertPgInt8Slice(request.UsersId))
+		return err
+	})
+	if err != nil {
+		return database.Task{}, []database.User{}, err
+	}
+
+	return task, users, nil
+}
+
+// DeleteTask remove uma tarefa junto com subtarefas, pivôs, comentários, anexos e notificações relacionados.
+// Retorna os anexos removidos, cujos arquivos ainda estão no storage.
+func DeleteTask(ctx context.Context, id int64) ([]database.Attachment, error) {
+	var attachments []database.Attachment
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		var err error
+		attachments, err = DeleteTasksRelations(ctx, queries, []int64{id})
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteTask(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// DeleteTasksRelations remove os registros das tarefas e subtarefas que não são apagados em cascata pelas foreign keys
+// e retorna os anexos removidos. Deve ser chamado com as queries de uma transação.
+func DeleteTasksRelations(ctx context.Context, queries *database.Queries, taskIds []int64) ([]database.Attachment, error) {
+	if len(taskIds) == 0 {
+		return nil, nil
+	}
+
+	subtaskIds, err := queries.FindSubtaskIdsByTaskIds(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	subtaskAttachments, err := morphRepository.DeleteMorphRelations(ctx, queries, morphTypes.Subtask, subtaskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	taskAttachments, err := morphRepository.DeleteMorphRelations(ctx, queries, morphTypes.Task, taskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := queries.DeleteUserTasksByTaskIds(ctx, taskIds); err != nil {
+		return nil, err
+	}
+
+	return append(subtaskAttachments, taskAttachments...), nil
+}
+
+// createUserTasks cria as relações entre a tarefa e os usuários informados
+func createUserTasks(ctx context.Context, queries *database.Queries, taskId int64, usersId []pgtype.Int8) error {
+	for _, userId := range usersId {
+		err := queries.CreateUserTask(ctx, database.CreateUserTaskParams{
+			UserID: userId,
+			TaskID: pgtype.Int8{
+				Int64: taskId,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,136 @@
+package templateRepository
+
+import (
+	"context"
+
+	"sixTask/internal/database"
+	"sixTask/internal/entity/templateEntity"
+)
+
+// PaginationResult contém os templates paginados e os metadados de paginação
+type PaginationResult struct {
+	Data []templateEntity.Template `json:"data"`
+	Meta PaginationMeta            `json:"meta"`
+}
+
+// PaginationMeta contém os metadados de paginação
+type PaginationMeta struct {
+	CurrentPage int   `json:"current_page"`
+	PerPage     int   `json:"per_page"`
+	Total       int64 `json:"total"`
+	TotalPages  int   `json:"total_pages"`
+}
+
+// GetTemplatesWithPagination retorna os templates em ordem alfabética de título
+func GetTemplatesWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+
+	// Validação dos parâmetros
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	// Cálculo do offset
+	offset := (page - 1) * limit
+
+	// Buscar o total de templates para metadados de paginação
+	total, err := queries.CountTemplates(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Calcular o total de páginas
+	totalPages := (int(total) + limit - 1) / limit
+
+	// Buscar templates com paginação via SQL
+	paginatedTemplates, err := queries.FindTemplatesWithPagination(ctx, database.FindTemplatesWithPaginationParams{
+		Offset: int32(offset),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return PaginationResult{}, err
+	}
+
+	// Montar resultado com metadados de paginação
+	result := PaginationResult{
+		Data: templateEntity.FromDatabaseTemplates(paginatedTemplates),
+		Meta: PaginationMeta{
+			CurrentPage: page,
+			PerPage:     limit,
+			Total:       total,
+			TotalPages:  totalPages,
+		},
+	}
+
+	return result, nil
+}
+
+// GetTemplate retorna um template de importação pelo ID
+func GetTemplate(ctx context.Context, id int64) (database.Template, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Template{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FindTemplateById(ctx, id)
+}
+
+// GetTableColumns retorna as colunas da tabela informada, na ordem de criação.
+// Uma tabela inexistente retorna uma lista vazia.
+func GetTableColumns(ctx context.Context, table string) ([]database.FindTableColumnsRow, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FindTableColumns(ctx, table)
+}
+
+// CreateTemplate cria um novo template de importação
+func CreateTemplate(ctx context.Context, params database.CreateTemplateParams) (database.Template, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Template{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.CreateTemplate(ctx, params)
+}
+
+// UpdateTemplate atualiza um template de importação existente
+func UpdateTemplate(ctx context.Context, params database.UpdateTemplateParams) (database.Template, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.Template{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.UpdateTemplate(ctx, params)
+}
+
+// DeleteTemplate remove um template; as importações feitas com ele são mantidas sem o template
+func DeleteTemplate(ctx context.Context, id int64) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.DeleteTemplate(ctx, id)
+}
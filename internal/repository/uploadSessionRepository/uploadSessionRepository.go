@@ -0,0 +1,143 @@
+package uploadSessionRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+)
+
+var (
+	// ErrOffsetMismatch indica que o bloco não começa no offset atual da sessão ou ultrapassa o tamanho declarado
+	ErrOffsetMismatch = errors.New("offset do bloco não confere com a sessão de upload")
+	// ErrSessionNotClaimable indica uma sessão que já está sendo finalizada ou não existe mais
+	ErrSessionNotClaimable = errors.New("sessão de upload já está sendo finalizada")
+)
+
+// CreateUploadSession cria uma nova sessão de upload
+func CreateUploadSession(ctx context.Context, params database.CreateUploadSessionParams) (database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.CreateUploadSession(ctx, params)
+}
+
+// GetUploadSession retorna uma sessão de upload pelo ID
+func GetUploadSession(ctx context.Context, id string) (database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.FindUploadSessionById(ctx, id)
+}
+
+// AppendChunk registra um bloco gravado no storage e avança o offset da sessão.
+// A atualização só ocorre se o offset informado ainda for o atual, evitando que
+// dois envios simultâneos do mesmo bloco sejam contabilizados.
+func AppendChunk(ctx context.Context, params database.AppendUploadChunkParams) (database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	session, err := queries.AppendUploadChunk(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.UploadSession{}, ErrOffsetMismatch
+	}
+
+	return session, err
+}
+
+// ClaimUploadSession marca a sessão completa como em finalização e estende sua validade, retornando
+// ErrSessionNotClaimable se ela não existir, estiver expirada, incompleta ou já em finalização.
+// Apenas uma chamada concorrente consegue reivindicar a mesma sessão.
+func ClaimUploadSession(ctx context.Context, id string, expiresAt time.Time) (database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	session, err := queries.ClaimUploadSession(ctx, database.ClaimUploadSessionParams{
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt.UTC(), Valid: true},
+		ID:        id,
+		Now:       pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.UploadSession{}, ErrSessionNotClaimable
+	}
+
+	return session, err
+}
+
+// ReleaseUploadSession devolve a sessão em finalização para o estado de envio, após uma falha na montagem
+func ReleaseUploadSession(ctx context.Context, id string) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.ReleaseUploadSession(ctx, id)
+}
+
+// DeleteUploadSession remove uma sessão de upload
+func DeleteUploadSession(ctx context.Context, id string) error {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.DeleteUploadSession(ctx, id)
+}
+
+// CancelUploadSession remove a sessão, desde que ela não esteja em finalização, e a retorna.
+// Retorna ErrSessionNotClaimable se a sessão não existir ou já estiver sendo finalizada.
+func CancelUploadSession(ctx context.Context, id string) (database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	session, err := queries.CancelUploadSession(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.UploadSession{}, ErrSessionNotClaimable
+	}
+
+	return session, err
+}
+
+// DeleteExpiredUploadSessions remove até limit sessões expiradas, das mais antigas para as mais recentes,
+// e as retorna para que os blocos sejam apagados. Sessões bloqueadas por outra transação são ignoradas.
+func DeleteExpiredUploadSessions(ctx context.Context, limit int32) ([]database.UploadSession, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+	return queries.DeleteExpiredUploadSessions(ctx, database.DeleteExpiredUploadSessionsParams{
+		Now:        pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+		LimitCount: limit,
+	})
+}
@@ -2,14 +2,24 @@ package userRepository
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	authhelper "sixTask/helpers/authHelper"
 	"sixTask/internal/database"
+	"sixTask/internal/entity/userEntity"
+	"sixTask/internal/http/request/userRequest"
 )
 
+// ErrEmailTaken indica que o email informado já pertence a outro usuário
+var ErrEmailTaken = errors.New("email já cadastrado")
+
 // PaginationResult contém os usuários paginados e os metadados de paginação
 type PaginationResult struct {
-	Data []database.User `json:"data"`
-	Meta PaginationMeta  `json:"meta"`
+	Data []userEntity.User `json:"data"`
+	Meta PaginationMeta    `json:"meta"`
 }
 
 // PaginationMeta contém os metadados de paginação
@@ -22,8 +32,11 @@ type PaginationMeta struct {
 
 // GetUsersWithPagination retorna os usuários paginados e os metadados de paginação
 func GetUsersWithPagination(ctx context.Context, page, limit int) (PaginationResult, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return PaginationResult{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 
@@ -58,7 +71,7 @@ func GetUsersWithPagination(ctx context.Context, page, limit int) (PaginationRes
 
 	// Montar resultado com metadados de paginação
 	result := PaginationResult{
-		Data: paginatedUsers,
+		Data: userEntity.FromDatabaseUsers(paginatedUsers),
 		Meta: PaginationMeta{
 			CurrentPage: page,
 			PerPage:     limit,
@@ -72,8 +85,11 @@ func GetUsersWithPagination(ctx context.Context, page, limit int) (PaginationRes
 
 // GetUsers retorna todos os usuários com paginação (mantido para compatibilidade)
 func GetUsers(ctx context.Context, offset, limit int32) ([]database.User, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindManyWithPagination(ctx, database.FindManyWithPaginationParams{
@@ -84,8 +100,11 @@ func GetUsers(ctx context.Context, offset, limit int32) ([]database.User, error)
 
 // CountUsers retorna o total de usuários (mantido para compatibilidade)
 func CountUsers(ctx context.Context) (int64, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.CountUsers(ctx)
@@ -93,8 +112,11 @@ func CountUsers(ctx context.Context) (int64, error) {
 
 // GetUser retorna um usuário pelo ID
 func GetUser(ctx context.Context, id int64) (database.User, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.User{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindById(ctx, id)
@@ -102,36 +124,127 @@ func GetUser(ctx context.Context, id int64) (database.User, error) {
 
 // GetUserByEmail retorna um usuário pelo email
 func GetUserByEmail(ctx context.Context, email string) (database.User, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return database.User{}, err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
 	return queries.FindByEmail(ctx, email)
 }
 
-// CreateUser cria um novo usuário
-func CreateUser(ctx context.Context, params database.CreateUserParams) (database.User, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// CreateUser cria um novo usuário com a senha protegida por bcrypt
+func CreateUser(ctx context.Context, request userRequest.CreateUserRequest) (database.User, error) {
+	passwordHash, err := authhelper.HashPassword(request.Password)
+	if err != nil {
+		return database.User{}, err
+	}
 
-	queries := database.New(conn)
-	return queries.CreateUser(ctx, params)
+	params := request.ToCreateUserParams(passwordHash).(database.CreateUserParams)
+
+	var user database.User
+	err = database.RunInTx(ctx, func(queries *database.Queries) error {
+		if err := ensureEmailAvailable(ctx, queries, params.Email, 0); err != nil {
+			return err
+		}
+
+		user, err = queries.CreateUser(ctx, params)
+		return err
+	})
+	if isUniqueViolation(err) {
+		return database.User{}, ErrEmailTaken
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+
+	return user, nil
 }
 
-// UpdateUser atualiza um usuário existente
-func UpdateUser(ctx context.Context, params database.UpdateUserParams) (database.User, error) {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+// UpdateUser atualiza um usuário existente.
+// Quando a senha é alterada todas as sessões do usuário são revogadas.
+func UpdateUser(ctx context.Context, request userRequest.UpdateUserRequest, id int64) (database.User, error) {
+	var passwordHash string
+	if request.Password != "" {
+		hash, err := authhelper.HashPassword(request.Password)
+		if err != nil {
+			return database.User{}, err
+		}
+		passwordHash = hash
+	}
 
-	queries := database.New(conn)
-	return queries.UpdateUser(ctx, params)
+	var user database.User
+	err := database.RunInTx(ctx, func(queries *database.Queries) error {
+		current, err := queries.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		params := request.ToUpdateUserParams(current, passwordHash).(database.UpdateUserParams)
+		if err := ensureEmailAvailable(ctx, queries, params.Email, id); err != nil {
+			return err
+		}
+
+		user, err = queries.UpdateUser(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if passwordHash != "" {
+			return queries.RevokeUserRefreshTokens(ctx, id)
+		}
+
+		return nil
+	})
+	if isUniqueViolation(err) {
+		return database.User{}, ErrEmailTaken
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+
+	return user, nil
 }
 
-// DeleteUser remove um usuário
+// DeleteUser remove um usuário; retorna pgx.ErrNoRows se o usuário não existir
 func DeleteUser(ctx context.Context, id int64) error {
-	conn, ctx := database.ConnectDB()
-	defer conn.Close(context.Background())
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
 
 	queries := database.New(conn)
-	return queries.DeleteUser(ctx, id)
+	removed, err := queries.DeleteUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ensureEmailAvailable verifica se o email não pertence a outro usuário
+func ensureEmailAvailable(ctx context.Context, queries *database.Queries, email string, ignoreID int64) error {
+	exists, err := queries.ExistsUserByEmail(ctx, database.ExistsUserByEmailParams{
+		Email:    email,
+		IgnoreID: ignoreID,
+	})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrEmailTaken
+	}
+
+	return nil
+}
+
+// isUniqueViolation identifica a violação da restrição de email único (requisições concorrentes)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
 }
@@ -0,0 +1,106 @@
+package userTokenRepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	authhelper "sixTask/helpers/authHelper"
+	"sixTask/internal/database"
+	"sixTask/internal/types/tokenTypes"
+)
+
+// ErrInvalidToken indica um token inexistente, expirado ou já utilizado
+var ErrInvalidToken = errors.New("token inválido ou expirado")
+
+// IssueToken gera um token de uso único para o usuário, invalidando os tokens anteriores do mesmo tipo.
+// Apenas a assinatura do token é armazenada; o valor retornado deve ser enviado ao usuário.
+func IssueToken(ctx context.Context, userID int64, tokenType string, ttl time.Duration) (string, error) {
+	raw, _, err := authhelper.GenerateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = database.RunInTx(ctx, func(queries *database.Queries) error {
+		err := queries.InvalidateUserTokens(ctx, database.InvalidateUserTokensParams{
+			UserID: userID,
+			Type:   tokenType,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.CreateUserToken(ctx, database.CreateUserTokenParams{
+			UserID:    userID,
+			Type:      tokenType,
+			TokenHash: authhelper.SignToken(raw),
+			ExpiresAt: pgtype.Timestamp{Time: time.Now().UTC().Add(ttl), Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// ResetPassword troca a senha do dono do token de redefinição e revoga todas as suas sessões.
+// A senha deve ser informada já com hash.
+func ResetPassword(ctx context.Context, rawToken, passwordHash string) error {
+	return database.RunInTx(ctx, func(queries *database.Queries) error {
+		token, err := consumeToken(ctx, queries, rawToken, tokenTypes.PasswordReset)
+		if err != nil {
+			return err
+		}
+
+		err = queries.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+			Password: passwordHash,
+			ID:       token.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.RevokeUserRefreshTokens(ctx, token.UserID)
+	})
+}
+
+// VerifyEmail confirma o email do dono do token de verificação
+func VerifyEmail(ctx context.Context, rawToken string) error {
+	return database.RunInTx(ctx, func(queries *database.Queries) error {
+		token, err := consumeToken(ctx, queries, rawToken, tokenTypes.EmailVerification)
+		if err != nil {
+			return err
+		}
+
+		return queries.MarkUserEmailVerified(ctx, token.UserID)
+	})
+}
+
+// consumeToken valida o token e o marca como utilizado dentro da transação
+func consumeToken(ctx context.Context, queries *database.Queries, rawToken, tokenType string) (database.UserToken, error) {
+	token, err := queries.FindUserTokenByHashForUpdate(ctx, database.FindUserTokenByHashForUpdateParams{
+		TokenHash: authhelper.SignToken(rawToken),
+		Type:      tokenType,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.UserToken{}, ErrInvalidToken
+	}
+	if err != nil {
+		return database.UserToken{}, err
+	}
+
+	if token.UsedAt.Valid || !token.ExpiresAt.Time.After(time.Now().UTC()) {
+		return database.UserToken{}, ErrInvalidToken
+	}
+
+	if err := queries.MarkUserTokenUsed(ctx, token.ID); err != nil {
+		return database.UserToken{}, err
+	}
+
+	return token, nil
+}
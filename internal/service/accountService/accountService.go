@@ -0,0 +1,128 @@
+package accountService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"sixTask/config/appConfig"
+	emailprovider "sixTask/config/emailProvider"
+	"sixTask/config/queue"
+	authhelper "sixTask/helpers/authHelper"
+	"sixTask/internal/database"
+	"sixTask/internal/http/request/userRequest"
+	"sixTask/internal/repository/userRepository"
+	"sixTask/internal/repository/userTokenRepository"
+	"sixTask/internal/types/tokenTypes"
+)
+
+// ErrAlreadyVerified indica que o email do usuário já foi confirmado
+var ErrAlreadyVerified = errors.New("email já confirmado")
+
+// AccountMailJob é o job de envio dos emails de conta (redefinição de senha e confirmação de email).
+// O payload leva apenas o usuário e o tipo do token: o token é gerado pelo worker no momento do envio,
+// de modo que nenhum segredo fica armazenado na fila ou visível no /monitor.
+var AccountMailJob = queue.Job[AccountMailPayload]{
+	Name:       "account:mail",
+	Queue:      "default",
+	MaxRetry:   8,
+	Timeout:    2 * time.Minute,
+	Retention:  24 * time.Hour,
+	RetryDelay: emailprovider.RetryDelay,
+}
+
+// AccountMailPayload é o conteúdo da tarefa de envio de email de conta
+type AccountMailPayload struct {
+	UserID    int64  `json:"user_id"`
+	TokenType string `json:"token_type"`
+	Locale    string `json:"locale,omitempty"`
+}
+
+// RequestPasswordReset enfileira o envio do link de redefinição de senha para o email informado.
+// Emails não cadastrados são ignorados silenciosamente para não revelar quais contas existem.
+func RequestPasswordReset(ctx context.Context, email, locale string) error {
+	user, err := userRepository.GetUserByEmail(ctx, userRequest.NormalizeEmail(email))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = AccountMailJob.Enqueue(ctx, AccountMailPayload{UserID: user.ID, TokenType: tokenTypes.PasswordReset, Locale: locale})
+	return err
+}
+
+// ResetPassword define a nova senha do usuário dono do token
+func ResetPassword(ctx context.Context, token, password string) error {
+	passwordHash, err := authhelper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return userTokenRepository.ResetPassword(ctx, token, passwordHash)
+}
+
+// SendEmailVerification enfileira o envio do link de confirmação de email para o usuário
+func SendEmailVerification(ctx context.Context, user database.User, locale string) error {
+	if user.EmailVerifiedAt.Valid {
+		return ErrAlreadyVerified
+	}
+
+	_, err := AccountMailJob.Enqueue(ctx, AccountMailPayload{UserID: user.ID, TokenType: tokenTypes.EmailVerification, Locale: locale})
+	return err
+}
+
+// DeliverAccountMail gera o token de uso único e envia o email de conta descrito no payload.
+// Cada tentativa gera um novo token, invalidando o da tentativa anterior. Emails já confirmados
+// não recebem um novo link de confirmação.
+func DeliverAccountMail(ctx context.Context, payload AccountMailPayload) error {
+	user, err := userRepository.GetUser(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	var template, path string
+	var ttl time.Duration
+	switch payload.TokenType {
+	case tokenTypes.PasswordReset:
+		template, path, ttl = "password_reset", "/reset-password", authhelper.GetPasswordResetTTL()
+	case tokenTypes.EmailVerification:
+		if user.EmailVerifiedAt.Valid {
+			return nil
+		}
+		template, path, ttl = "email_verification", "/verify-email", authhelper.GetEmailVerificationTTL()
+	default:
+		return fmt.Errorf("%w: tipo de token desconhecido: %q", emailprovider.ErrInvalidMessage, payload.TokenType)
+	}
+
+	token, err := userTokenRepository.IssueToken(ctx, user.ID, payload.TokenType, ttl)
+	if err != nil {
+		return err
+	}
+
+	return emailprovider.SendMail(ctx, emailprovider.EmailMessage{
+		To:       []string{user.Email},
+		Template: template,
+		Locale:   payload.Locale,
+		TemplateData: map[string]interface{}{
+			"Nome":            user.Name,
+			"Link":            buildLink(path, token),
+			"ExpiraEmMinutos": int(ttl.Minutes()),
+		},
+	})
+}
+
+// VerifyEmail confirma o email do usuário dono do token
+func VerifyEmail(ctx context.Context, token string) error {
+	return userTokenRepository.VerifyEmail(ctx, token)
+}
+
+// buildLink monta o link do frontend (APP_URL) que recebe o token
+func buildLink(path, token string) string {
+	return appConfig.Get().App.URL + path + "?token=" + url.QueryEscape(token)
+}
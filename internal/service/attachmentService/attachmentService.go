@@ -0,0 +1,221 @@
+package attachmentService
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/storageProvider"
+	signedurl "sixTask/helpers/signedUrl"
+	"sixTask/internal/database"
+	"sixTask/internal/http/request/attachmentRequest"
+	"sixTask/internal/repository/attachmentRepository"
+	"sixTask/internal/types/attachmentStatusTypes"
+)
+
+var (
+	// ErrScanPending indica que o anexo ainda aguarda a verificação antivírus
+	ErrScanPending = errors.New("anexo aguardando verificação antivírus")
+	// ErrQuarantined indica que o anexo foi bloqueado pela verificação antivírus
+	ErrQuarantined = errors.New("anexo bloqueado: arquivo infectado em quarentena")
+)
+
+// MaxURLTTL é a validade máxima de um link assinado de anexo
+const MaxURLTTL = appConfig.MaxAttachmentURLTTL
+
+// DefaultURLTTL retorna a validade padrão dos links assinados (ATTACHMENT_URL_TTL, padrão 15m)
+func DefaultURLTTL() time.Duration {
+	return appConfig.Get().Upload.AttachmentURLTTL
+}
+
+// SignedURL monta o link público e temporário de download do anexo
+func SignedURL(id int64, expiresAt time.Time) string {
+	query := signedurl.Sign(attachmentResource(id), expiresAt)
+	return fmt.Sprintf("/api/files/attachments/%d?%s", id, query.Encode())
+}
+
+// VerifySignedURL confere a assinatura e a validade de um link de download do anexo
+func VerifySignedURL(id int64, query url.Values) error {
+	return signedurl.Verify(attachmentResource(id), query)
+}
+
+// attachmentResource identifica o anexo nas assinaturas
+func attachmentResource(id int64) string {
+	return fmt.Sprintf("attachment:%d", id)
+}
+
+// Upload valida o arquivo contra a política do tipo anexável e as cotas de armazenamento,
+// grava o arquivo no storage e cria o registro do anexo. Tipo, tamanho e checksum (SHA-256)
+// são calculados pelo servidor; se o registro não puder ser criado, o arquivo gravado é removido.
+// Com um scanner configurado o anexo nasce pendente e só pode ser baixado após a verificação.
+func Upload(ctx context.Context, request attachmentRequest.CreateAttachmentRequest, ownerID pgtype.Int8) (database.Attachment, error) {
+	store, err := storageProvider.Default()
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	status, err := initialStatus()
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	src, err := request.File.Open()
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	defer src.Close()
+
+	file, err := storeFile(ctx, store, src, request.File.Size, request.Filename(), ownerID, request.AttachableType, request.AttachableID)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	params := request.ToCreateAttachmentParams(ownerID, file.Key, file.Size, file.Filetype, file.Checksum, status).(database.CreateAttachmentParams)
+	return createAttachment(ctx, store, params)
+}
+
+// storedFile descreve um arquivo gravado por storeFile
+type storedFile struct {
+	Key      string
+	Size     int64
+	Filetype string
+	Checksum string
+}
+
+// storeFile valida o conteúdo contra a política do tipo anexável e as cotas do dono e do projeto
+// e grava o arquivo no storage. O tipo é identificado pelo conteúdo, ignorando o Content-Type
+// enviado pelo cliente, e o checksum é calculado enquanto o arquivo é gravado.
+func storeFile(ctx context.Context, store storageProvider.Storage, reader io.Reader, size int64, filename string, ownerID pgtype.Int8, attachableType string, attachableID int64) (storedFile, error) {
+	filetype, reader, err := PolicyFor(attachableType).CheckStream(reader, size)
+	if err != nil {
+		return storedFile{}, err
+	}
+
+	if err := checkQuota(ctx, ownerID, attachableType, attachableID, size); err != nil {
+		return storedFile{}, err
+	}
+
+	subPath := path.Join("attachments", attachableType, time.Now().Format("2006/01"))
+	key, err := storageProvider.NewKey(subPath, filename)
+	if err != nil {
+		return storedFile{}, err
+	}
+
+	hasher := sha256.New()
+	counter := &countingReader{reader: io.TeeReader(reader, hasher)}
+	if err := store.Put(ctx, key, counter, size, filetype); err != nil {
+		return storedFile{}, fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+
+	return storedFile{
+		Key:      key,
+		Size:     counter.size,
+		Filetype: filetype,
+		Checksum: hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
+
+// createAttachment cria o registro do arquivo gravado e dispara a verificação antivírus
+// ou, para anexos já liberados, a geração das miniaturas. As cotas são verificadas novamente
+// na transação que cria o registro, o que impede que uploads simultâneos as ultrapassem juntos.
+// Se o registro não puder ser criado, o arquivo gravado é removido.
+func createAttachment(ctx context.Context, store storageProvider.Storage, params database.CreateAttachmentParams) (database.Attachment, error) {
+	attachment, err := attachmentRepository.CreateAttachmentWithinQuota(ctx, params, func(usage attachmentRepository.StorageUsage) error {
+		return quotaError(usage, params.Filesize)
+	})
+	if err != nil {
+		removeFile(store, params.Filepath)
+		return database.Attachment{}, err
+	}
+
+	// Se a fila estiver indisponível o anexo continua pendente; a verificação pode ser reenfileirada depois
+	if attachment.Status == attachmentStatusTypes.Pending {
+		if err := EnqueueScan(ctx, attachment.ID); err != nil {
+			slog.ErrorContext(ctx, "Erro ao enfileirar verificação do anexo", "attachment_id", attachment.ID, "error", err)
+		}
+	} else {
+		afterClean(ctx, attachment)
+	}
+
+	return attachment, nil
+}
+
+// CheckDownloadable informa se o arquivo do anexo pode ser entregue: anexos pendentes
+// de verificação ou em quarentena não podem ser baixados
+func CheckDownloadable(attachment database.Attachment) error {
+	switch attachment.Status {
+	case attachmentStatusTypes.Clean:
+		return nil
+	case attachmentStatusTypes.Pending:
+		return ErrScanPending
+	default:
+		return ErrQuarantined
+	}
+}
+
+// Delete remove o registro do anexo e o arquivo armazenado
+func Delete(ctx context.Context, id int64) error {
+	attachment, err := attachmentRepository.GetAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := attachmentRepository.DeleteAttachment(ctx, id); err != nil {
+		return err
+	}
+
+	RemoveFiles(ctx, []database.Attachment{attachment})
+
+	return nil
+}
+
+// RemoveFiles apaga do storage os arquivos e as miniaturas de anexos cujos registros já foram removidos,
+// como os retornados pela remoção de tarefas, subtarefas, projetos e clientes. Deve ser chamado depois
+// do commit; falhas são apenas registradas, pois os registros não existem mais.
+func RemoveFiles(ctx context.Context, attachments []database.Attachment) {
+	if len(attachments) == 0 {
+		return
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		for _, attachment := range attachments {
+			slog.ErrorContext(ctx, "Anexo removido, mas o storage está indisponível para remover o arquivo", "attachment_id", attachment.ID, "key", attachment.Filepath, "error", err)
+		}
+		return
+	}
+
+	for _, attachment := range attachments {
+		removeFile(store, attachment.Filepath)
+		removeThumbnails(store, attachment)
+	}
+}
+
+// removeFile remove o arquivo do storage; falhas são apenas registradas, pois o registro já foi tratado
+func removeFile(store storageProvider.Storage, key string) {
+	if err := store.Delete(context.Background(), key); err != nil {
+		slog.Error("Erro ao remover arquivo do storage", "key", key, "error", err)
+	}
+}
+
+// countingReader conta os bytes lidos
+type countingReader struct {
+	reader io.Reader
+	size   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	return n, err
+}
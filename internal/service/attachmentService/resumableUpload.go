@@ -0,0 +1,354 @@
+package attachmentService
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/queue"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/http/request/uploadSessionRequest"
+	"sixTask/internal/repository/uploadSessionRepository"
+)
+
+// CleanupUploadsJob é o job agendado que remove as sessões de upload expiradas; não tem payload
+var CleanupUploadsJob = queue.Job[struct{}]{
+	Name:     "uploads:cleanup",
+	Queue:    "default",
+	MaxRetry: 1,
+}
+
+// cleanupBatchSize é a quantidade de sessões expiradas removidas por consulta
+const cleanupBatchSize = 100
+
+var (
+	// ErrUploadSessionNotFound indica uma sessão inexistente, expirada ou de outro usuário
+	ErrUploadSessionNotFound = errors.New("sessão de upload não encontrada")
+	// ErrChunkExceedsLength indica um bloco que ultrapassa o tamanho declarado na sessão
+	ErrChunkExceedsLength = errors.New("bloco ultrapassa o tamanho declarado do arquivo")
+	// ErrUploadIncomplete indica a finalização de uma sessão que ainda não recebeu todos os bytes
+	ErrUploadIncomplete = errors.New("upload incompleto")
+	// ErrUploadFinishing indica uma sessão que já está sendo finalizada por outra requisição
+	ErrUploadFinishing = errors.New("upload já está sendo finalizado")
+)
+
+// UploadSessionTTL retorna por quanto tempo uma sessão sem novos blocos é mantida (UPLOAD_SESSION_TTL, padrão 24h)
+func UploadSessionTTL() time.Duration {
+	return appConfig.Get().Upload.SessionTTL
+}
+
+// MaxChunkSize retorna o tamanho máximo de cada bloco (UPLOAD_CHUNK_MAX_SIZE, padrão 16MB)
+func MaxChunkSize() int64 {
+	return appConfig.Get().Upload.ChunkMaxSize
+}
+
+// StartUpload abre uma sessão de upload em blocos. O tamanho declarado é validado contra
+// a política do tipo anexável e as cotas já na abertura, antes de qualquer byte ser enviado.
+func StartUpload(ctx context.Context, request uploadSessionRequest.CreateUploadSessionRequest, actorID int64) (database.UploadSession, error) {
+	policy := PolicyFor(request.AttachableType)
+	if policy.MaxSize > 0 && request.Size > policy.MaxSize {
+		return database.UploadSession{}, fmt.Errorf("%w (%s)", storageProvider.ErrFileTooLarge, storageProvider.FormatSize(policy.MaxSize))
+	}
+
+	if err := checkQuota(ctx, request.OwnerID(actorID), request.AttachableType, request.AttachableID, request.Size); err != nil {
+		return database.UploadSession{}, err
+	}
+
+	id, err := newUploadSessionID()
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+
+	params := request.ToCreateUploadSessionParams(id, actorID, time.Now().Add(UploadSessionTTL())).(database.CreateUploadSessionParams)
+	return uploadSessionRepository.CreateUploadSession(ctx, params)
+}
+
+// GetUpload retorna a sessão de upload do usuário
+func GetUpload(ctx context.Context, id string, actorID int64) (database.UploadSession, error) {
+	session, err := uploadSessionRepository.GetUploadSession(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.UploadSession{}, ErrUploadSessionNotFound
+	}
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+
+	// Sessões de outros usuários são tratadas como inexistentes
+	if session.CreatedBy != actorID || session.ExpiresAt.Time.Before(time.Now().UTC()) {
+		return database.UploadSession{}, ErrUploadSessionNotFound
+	}
+
+	return session, nil
+}
+
+// WriteChunk grava no storage um bloco que começa em offset e avança a sessão.
+// O bloco precisa chegar completo (size bytes); em caso de falha o cliente reenvia
+// o mesmo bloco a partir do offset atual, consultado com GetUpload.
+func WriteChunk(ctx context.Context, id string, actorID int64, offset int64, reader io.Reader, size int64) (database.UploadSession, error) {
+	session, err := GetUpload(ctx, id, actorID)
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+	if offset != session.UploadOffset {
+		return session, uploadSessionRepository.ErrOffsetMismatch
+	}
+	if offset+size > session.UploadLength {
+		return session, ErrChunkExceedsLength
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+
+	key, err := storageProvider.NewKey(chunkPrefix(session.ID), "")
+	if err != nil {
+		return database.UploadSession{}, err
+	}
+
+	counter := &countingReader{reader: reader}
+	if err := store.Put(ctx, key, counter, size, "application/octet-stream"); err != nil {
+		removeFile(store, key)
+		return database.UploadSession{}, fmt.Errorf("erro ao gravar bloco: %w", err)
+	}
+	if counter.size != size {
+		removeFile(store, key)
+		return database.UploadSession{}, fmt.Errorf("bloco incompleto: recebidos %d de %d bytes: %w", counter.size, size, io.ErrUnexpectedEOF)
+	}
+
+	session, err = uploadSessionRepository.AppendChunk(ctx, database.AppendUploadChunkParams{
+		ChunkSize:    size,
+		ChunkKey:     key,
+		ExpiresAt:    pgtype.Timestamp{Time: time.Now().UTC().Add(UploadSessionTTL()), Valid: true},
+		ID:           session.ID,
+		UploadOffset: offset,
+	})
+	if err != nil {
+		removeFile(store, key)
+		return database.UploadSession{}, err
+	}
+
+	return session, nil
+}
+
+// FinishUpload monta o arquivo a partir dos blocos, cria o anexo como em Upload e remove a sessão.
+// A sessão é reivindicada antes da montagem, de modo que finalizações simultâneas, o cancelamento
+// e a limpeza das sessões expiradas não a alcançam; os blocos só são apagados depois que o anexo
+// é criado. Em caso de falha a sessão volta a aceitar a finalização. authorize verifica novamente
+// a permissão de anexar ao registro da sessão, que pode ter sido revogada durante o envio.
+func FinishUpload(ctx context.Context, id string, actorID int64, authorize func(database.UploadSession) error) (database.Attachment, error) {
+	session, err := GetUpload(ctx, id, actorID)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+	if err := authorize(session); err != nil {
+		return database.Attachment{}, err
+	}
+	if session.UploadOffset != session.UploadLength {
+		return database.Attachment{}, fmt.Errorf("%w: recebidos %d de %d bytes", ErrUploadIncomplete, session.UploadOffset, session.UploadLength)
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	status, err := initialStatus()
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	session, err = uploadSessionRepository.ClaimUploadSession(ctx, session.ID, time.Now().Add(UploadSessionTTL()))
+	if errors.Is(err, uploadSessionRepository.ErrSessionNotClaimable) {
+		return database.Attachment{}, ErrUploadFinishing
+	}
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	attachment, err := assembleUpload(ctx, store, session, status)
+	if err != nil {
+		if releaseErr := uploadSessionRepository.ReleaseUploadSession(context.WithoutCancel(ctx), session.ID); releaseErr != nil {
+			slog.WarnContext(ctx, "Sessão de upload não foi liberada após falha na finalização", "upload_id", session.ID, "error", releaseErr)
+		}
+		return database.Attachment{}, err
+	}
+
+	if err := removeUploadSession(ctx, store, session); err != nil {
+		slog.WarnContext(ctx, "Anexo criado, mas a sessão de upload não foi removida", "attachment_id", attachment.ID, "upload_id", session.ID, "error", err)
+	}
+
+	return attachment, nil
+}
+
+// assembleUpload grava o arquivo montado a partir dos blocos da sessão reivindicada e cria o anexo
+func assembleUpload(ctx context.Context, store storageProvider.Storage, session database.UploadSession, status string) (database.Attachment, error) {
+	chunks := &chunkReader{ctx: ctx, store: store, keys: session.ChunkKeys}
+	defer chunks.Close()
+
+	file, err := storeFile(ctx, store, chunks, session.UploadLength, session.Filename, session.UserID, session.AttachableType, session.AttachableID)
+	if err != nil {
+		return database.Attachment{}, err
+	}
+
+	return createAttachment(ctx, store, database.CreateAttachmentParams{
+		Filename:       session.Filename,
+		Filepath:       file.Key,
+		Filesize:       file.Size,
+		Filetype:       file.Filetype,
+		Checksum:       pgtype.Text{String: file.Checksum, Valid: true},
+		Status:         status,
+		UserID:         session.UserID,
+		AttachableType: session.AttachableType,
+		AttachableID:   session.AttachableID,
+	})
+}
+
+// CancelUpload descarta a sessão de upload e os blocos já enviados. Sessões em finalização não podem ser canceladas.
+func CancelUpload(ctx context.Context, id string, actorID int64) error {
+	session, err := GetUpload(ctx, id, actorID)
+	if err != nil {
+		return err
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return err
+	}
+
+	session, err = uploadSessionRepository.CancelUploadSession(ctx, session.ID)
+	if errors.Is(err, uploadSessionRepository.ErrSessionNotClaimable) {
+		return ErrUploadFinishing
+	}
+	if err != nil {
+		return err
+	}
+
+	removeChunks(store, session)
+	return nil
+}
+
+// NewCleanupUploadsTask cria a tarefa que remove as sessões de upload expiradas
+func NewCleanupUploadsTask() *asynq.Task {
+	// Um payload vazio sempre é codificado
+	task, _ := CleanupUploadsJob.NewTask(struct{}{})
+	return task
+}
+
+// CleanupExpiredUploads remove as sessões expiradas e seus blocos, retornando quantas foram removidas
+func CleanupExpiredUploads(ctx context.Context) (int, error) {
+	store, err := storageProvider.Default()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for {
+		// As sessões são removidas do banco antes dos blocos; sessões em finalização têm a validade
+		// estendida ao serem reivindicadas e não são alcançadas aqui
+		sessions, err := uploadSessionRepository.DeleteExpiredUploadSessions(ctx, cleanupBatchSize)
+		if err != nil {
+			return removed, err
+		}
+
+		for _, session := range sessions {
+			removeChunks(store, session)
+			removed++
+		}
+
+		if len(sessions) < cleanupBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// removeUploadSession remove o registro da sessão e depois os blocos gravados
+func removeUploadSession(ctx context.Context, store storageProvider.Storage, session database.UploadSession) error {
+	if err := uploadSessionRepository.DeleteUploadSession(ctx, session.ID); err != nil {
+		return err
+	}
+
+	removeChunks(store, session)
+	return nil
+}
+
+// removeChunks apaga do storage os blocos gravados da sessão
+func removeChunks(store storageProvider.Storage, session database.UploadSession) {
+	for _, key := range session.ChunkKeys {
+		removeFile(store, key)
+	}
+}
+
+// chunkPrefix é o diretório do storage onde ficam os blocos da sessão
+func chunkPrefix(sessionID string) string {
+	return path.Join("uploads", sessionID)
+}
+
+// newUploadSessionID gera o identificador público da sessão
+func newUploadSessionID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// chunkReader lê os blocos da sessão em sequência, abrindo um arquivo do storage por vez
+type chunkReader struct {
+	ctx     context.Context
+	store   storageProvider.Storage
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+
+			file, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, fmt.Errorf("erro ao abrir bloco %s: %w", r.keys[0], err)
+			}
+			r.current, r.keys = file, r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		return n, err
+	}
+}
+
+// Close fecha o bloco aberto, se houver
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
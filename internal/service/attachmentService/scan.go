@@ -0,0 +1,148 @@
+package attachmentService
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/queue"
+	"sixTask/config/scannerProvider"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/repository/attachmentRepository"
+	"sixTask/internal/types/attachmentStatusTypes"
+)
+
+// quarantinePrefix é o diretório do storage para onde vão os arquivos infectados
+const quarantinePrefix = "quarantine"
+
+// ScanJob é o job de verificação antivírus processado pelo worker. O clamd pode estar
+// temporariamente indisponível, por isso são feitas até 10 novas tentativas.
+var ScanJob = queue.Job[ScanPayload]{
+	Name:     "attachment:scan",
+	Queue:    "default",
+	MaxRetry: 10,
+	Timeout:  10 * time.Minute,
+}
+
+// ScanPayload é o conteúdo da tarefa de verificação
+type ScanPayload struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+// EnqueueScan enfileira a verificação antivírus do anexo
+func EnqueueScan(ctx context.Context, id int64) error {
+	_, err := ScanJob.Enqueue(ctx, ScanPayload{AttachmentID: id})
+	return err
+}
+
+// initialStatus retorna a situação de um anexo recém-enviado: pendente quando há
+// scanner configurado, liberado para download caso contrário
+func initialStatus() (string, error) {
+	scanner, err := scannerProvider.Default()
+	if err != nil {
+		return "", err
+	}
+	if scanner == nil {
+		return attachmentStatusTypes.Clean, nil
+	}
+
+	return attachmentStatusTypes.Pending, nil
+}
+
+// Scan verifica o arquivo de um anexo pendente. Arquivos limpos são liberados para download;
+// arquivos infectados são movidos para a quarentena e o anexo fica bloqueado.
+// Erros do scanner ou do storage são retornados para que a tarefa seja repetida.
+func Scan(ctx context.Context, id int64) error {
+	attachment, err := attachmentRepository.GetAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.Status != attachmentStatusTypes.Pending {
+		return nil
+	}
+
+	scanner, err := scannerProvider.Default()
+	if err != nil {
+		return err
+	}
+
+	// Sem scanner configurado (ex.: desativado após o upload) o anexo é liberado sem verificação
+	if scanner == nil {
+		return markClean(ctx, attachment)
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return err
+	}
+
+	file, err := store.Get(ctx, attachment.Filepath)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo %s: %w", attachment.Filepath, err)
+	}
+	result, err := scanner.Scan(ctx, file)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar anexo %d: %w", id, err)
+	}
+
+	if result.Clean {
+		return markClean(ctx, attachment)
+	}
+
+	slog.WarnContext(ctx, "Anexo infectado; movendo para a quarentena", "attachment_id", id, "signature", result.Signature)
+
+	quarantineKey, err := quarantine(ctx, store, attachment)
+	if err != nil {
+		return err
+	}
+
+	return markScanned(ctx, attachment, attachmentStatusTypes.Quarantined, quarantineKey, result.Signature)
+}
+
+// quarantine copia o arquivo para o diretório de quarentena e remove o original
+func quarantine(ctx context.Context, store storageProvider.Storage, attachment database.Attachment) (string, error) {
+	key := path.Join(quarantinePrefix, attachment.Filepath)
+
+	file, err := store.Get(ctx, attachment.Filepath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if err := store.Put(ctx, key, file, attachment.Filesize, attachment.Filetype); err != nil {
+		return "", fmt.Errorf("erro ao mover anexo %d para a quarentena: %w", attachment.ID, err)
+	}
+	if err := store.Delete(ctx, attachment.Filepath); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// markClean libera o anexo para download e dispara a geração das miniaturas
+func markClean(ctx context.Context, attachment database.Attachment) error {
+	if err := markScanned(ctx, attachment, attachmentStatusTypes.Clean, attachment.Filepath, ""); err != nil {
+		return err
+	}
+
+	afterClean(ctx, attachment)
+	return nil
+}
+
+// markScanned registra a situação final do anexo
+func markScanned(ctx context.Context, attachment database.Attachment, status, key, signature string) error {
+	_, err := attachmentRepository.UpdateAttachmentScanResult(ctx, database.UpdateAttachmentScanResultParams{
+		Status:     status,
+		Filepath:   key,
+		ScanResult: pgtype.Text{String: signature, Valid: signature != ""},
+		ID:         attachment.ID,
+	})
+
+	return err
+}
@@ -0,0 +1,33 @@
+package attachmentService
+
+import (
+	"testing"
+
+	"sixTask/config/scannerProvider"
+	"sixTask/internal/types/attachmentStatusTypes"
+)
+
+func TestInitialStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		scanner scannerProvider.Scanner
+		want    string
+	}{
+		{"com scanner fica pendente", scannerProvider.NewFakeScanner(), attachmentStatusTypes.Pending},
+		{"sem scanner é liberado", nil, attachmentStatusTypes.Clean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scannerProvider.SetDefault(tt.scanner)
+
+			got, err := initialStatus()
+			if err != nil {
+				t.Fatalf("initialStatus() erro inesperado: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("initialStatus() = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package attachmentService
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"sixTask/config/queue"
+	"sixTask/config/storageProvider"
+	"sixTask/helpers/thumbnail"
+	"sixTask/internal/database"
+	"sixTask/internal/repository/attachmentRepository"
+	"sixTask/internal/types/attachmentStatusTypes"
+)
+
+// maxThumbnailSource limita o tamanho do arquivo lido para gerar a miniatura
+const maxThumbnailSource = 100 << 20
+
+// ThumbnailJob é o job de geração de miniaturas processado pelo worker
+var ThumbnailJob = queue.Job[ThumbnailPayload]{
+	Name:     "attachment:thumbnail",
+	Queue:    "default",
+	MaxRetry: 3,
+	Timeout:  5 * time.Minute,
+}
+
+// ErrThumbnailUnavailable indica um anexo sem miniatura: tipo sem suporte ou ainda não gerada
+var ErrThumbnailUnavailable = errors.New("miniatura não disponível")
+
+// ThumbnailPayload é o conteúdo da tarefa de geração de miniaturas
+type ThumbnailPayload struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+// EnqueueThumbnail enfileira a geração das miniaturas do anexo
+func EnqueueThumbnail(ctx context.Context, id int64) error {
+	_, err := ThumbnailJob.Enqueue(ctx, ThumbnailPayload{AttachmentID: id})
+	return err
+}
+
+// ThumbnailKey retorna a chave da miniatura, gravada ao lado do arquivo original
+// (ex.: attachments/task/2025/05/abc_thumb_small.jpg)
+func ThumbnailKey(key, size string) string {
+	base := strings.TrimSuffix(key, path.Ext(key))
+	return fmt.Sprintf("%s_thumb_%s.jpg", base, size)
+}
+
+// ThumbnailFor retorna a chave da miniatura do anexo no tamanho informado
+func ThumbnailFor(attachment database.Attachment, size string) (string, error) {
+	if !thumbnail.Supports(attachment.Filetype) || !attachment.ThumbnailGeneratedAt.Valid {
+		return "", ErrThumbnailUnavailable
+	}
+
+	return ThumbnailKey(attachment.Filepath, size), nil
+}
+
+// GenerateThumbnails gera e grava as miniaturas de um anexo liberado para download.
+// Tipos sem suporte e PDFs sem imagem são ignorados; erros de leitura ou gravação são repetidos.
+func GenerateThumbnails(ctx context.Context, id int64) error {
+	attachment, err := attachmentRepository.GetAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.Status != attachmentStatusTypes.Clean || !thumbnail.Supports(attachment.Filetype) {
+		return nil
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return err
+	}
+
+	source, err := readThumbnailSource(ctx, store, attachment)
+	if err != nil {
+		return err
+	}
+
+	img, err := thumbnail.Decode(source, attachment.Filetype)
+	if err != nil {
+		slog.InfoContext(ctx, "Anexo sem miniatura", "attachment_id", id, "error", err)
+		return nil
+	}
+
+	thumbnails, err := thumbnail.Generate(img)
+	if err != nil {
+		return err
+	}
+
+	for size, content := range thumbnails {
+		key := ThumbnailKey(attachment.Filepath, size)
+		if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content)), thumbnail.ContentType); err != nil {
+			return fmt.Errorf("erro ao gravar miniatura %s: %w", key, err)
+		}
+	}
+
+	return attachmentRepository.MarkThumbnailGenerated(ctx, id)
+}
+
+// readThumbnailSource lê o arquivo original para a memória, pois os decodificadores precisam de io.Seeker
+func readThumbnailSource(ctx context.Context, store storageProvider.Storage, attachment database.Attachment) (io.ReadSeeker, error) {
+	if attachment.Filesize > maxThumbnailSource {
+		return nil, fmt.Errorf("anexo %d grande demais para gerar miniatura", attachment.ID)
+	}
+
+	file, err := store.Get(ctx, attachment.Filepath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo %s: %w", attachment.Filepath, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxThumbnailSource))
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(content), nil
+}
+
+// afterClean dispara o processamento de um anexo liberado para download
+func afterClean(ctx context.Context, attachment database.Attachment) {
+	if !thumbnail.Supports(attachment.Filetype) {
+		return
+	}
+
+	if err := EnqueueThumbnail(ctx, attachment.ID); err != nil {
+		slog.ErrorContext(ctx, "Erro ao enfileirar miniaturas do anexo", "attachment_id", attachment.ID, "error", err)
+	}
+}
+
+// removeThumbnails remove as miniaturas do anexo, se existirem
+func removeThumbnails(store storageProvider.Storage, attachment database.Attachment) {
+	if !attachment.ThumbnailGeneratedAt.Valid {
+		return
+	}
+
+	for size := range thumbnail.Sizes {
+		removeFile(store, ThumbnailKey(attachment.Filepath, size))
+	}
+}
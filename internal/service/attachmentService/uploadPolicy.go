@@ -0,0 +1,84 @@
+package attachmentService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/repository/attachmentRepository"
+	"sixTask/internal/types/morphTypes"
+)
+
+// ErrQuotaExceeded indica que o upload ultrapassaria a cota de armazenamento do usuário ou do projeto
+var ErrQuotaExceeded = errors.New("cota de armazenamento excedida")
+
+// documentTypes são os tipos aceitos nos anexos de projetos, tarefas e subtarefas
+var documentTypes = []string{
+	"image/*",
+	"application/pdf",
+	"text/plain",
+	"text/csv",
+	"application/zip",
+	"application/vnd.openxmlformats-officedocument.*",
+	"application/vnd.oasis.opendocument.*",
+	"application/vnd.ms-excel",
+	"application/vnd.ms-powerpoint",
+	"application/msword",
+}
+
+// PolicyFor retorna a política de upload do tipo de registro anexável.
+// Os tamanhos podem ser ajustados por ATTACHMENT_MAX_SIZE_<TIPO> (ex.: ATTACHMENT_MAX_SIZE_TASK=50MB).
+func PolicyFor(attachableType string) storageProvider.UploadPolicy {
+	switch attachableType {
+	case morphTypes.Project:
+		return storageProvider.UploadPolicy{
+			MaxSize:      appConfig.Get().Upload.AttachmentMaxSizeProject,
+			AllowedTypes: documentTypes,
+		}
+	case morphTypes.Task, morphTypes.Subtask:
+		return storageProvider.UploadPolicy{
+			MaxSize:      appConfig.Get().Upload.AttachmentMaxSizeTask,
+			AllowedTypes: documentTypes,
+		}
+	default:
+		return storageProvider.DefaultUploadPolicy()
+	}
+}
+
+// UserQuota retorna a cota de armazenamento por usuário (USER_STORAGE_QUOTA, padrão 1GB)
+func UserQuota() int64 {
+	return appConfig.Get().Upload.UserQuota
+}
+
+// ProjectQuota retorna a cota de armazenamento por projeto (PROJECT_STORAGE_QUOTA, padrão 5GB)
+func ProjectQuota() int64 {
+	return appConfig.Get().Upload.ProjectQuota
+}
+
+// checkQuota verifica se o novo arquivo cabe nas cotas do dono e do projeto do registro anexável,
+// antes de gravá-lo no storage. O uso é calculado a partir da tabela attachments, incluindo anexos
+// em quarentena. A verificação definitiva é refeita por createAttachment, na transação que cria o anexo.
+func checkQuota(ctx context.Context, ownerID pgtype.Int8, attachableType string, attachableID int64, size int64) error {
+	usage, err := attachmentRepository.GetStorageUsage(ctx, ownerID, attachableType, attachableID)
+	if err != nil {
+		return err
+	}
+
+	return quotaError(usage, size)
+}
+
+// quotaError retorna ErrQuotaExceeded se o novo arquivo não couber no uso informado
+func quotaError(usage attachmentRepository.StorageUsage, size int64) error {
+	if quota := UserQuota(); usage.User+size > quota {
+		return fmt.Errorf("%w: o usuário já utiliza %s de %s", ErrQuotaExceeded, storageProvider.FormatSize(usage.User), storageProvider.FormatSize(quota))
+	}
+	if quota := ProjectQuota(); usage.ProjectID.Valid && usage.Project+size > quota {
+		return fmt.Errorf("%w: o projeto já utiliza %s de %s", ErrQuotaExceeded, storageProvider.FormatSize(usage.Project), storageProvider.FormatSize(quota))
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package attachmentService
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/internal/repository/attachmentRepository"
+)
+
+func TestQuotaError(t *testing.T) {
+	appConfig.Set(&appConfig.Config{Upload: appConfig.UploadConfig{UserQuota: 100, ProjectQuota: 200}})
+
+	project := pgtype.Int8{Int64: 7, Valid: true}
+
+	tests := []struct {
+		name    string
+		usage   attachmentRepository.StorageUsage
+		size    int64
+		wantErr bool
+	}{
+		{"cabe nas cotas", attachmentRepository.StorageUsage{User: 40, ProjectID: project, Project: 150}, 50, false},
+		{"preenche a cota do usuário", attachmentRepository.StorageUsage{User: 40}, 60, false},
+		{"ultrapassa a cota do usuário", attachmentRepository.StorageUsage{User: 40}, 61, true},
+		{"ultrapassa a cota do projeto", attachmentRepository.StorageUsage{User: 0, ProjectID: project, Project: 190}, 11, true},
+		{"sem projeto ignora a cota do projeto", attachmentRepository.StorageUsage{User: 0, Project: 190}, 11, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := quotaError(tt.usage, tt.size)
+			if tt.wantErr != errors.Is(err, ErrQuotaExceeded) {
+				t.Errorf("quotaError() = %v, esperado erro: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
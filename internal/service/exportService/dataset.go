@@ -0,0 +1,133 @@
+package exportService
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/internal/database"
+	"sixTask/internal/types/exportTypes"
+)
+
+// Consultas das exportações. Os filtros são opcionais: parâmetros NULL não filtram, de modo
+// que cada consulta atende às mesmas combinações das rotas de listagem. Os apelidos das
+// colunas são usados como cabeçalho da planilha.
+const (
+	exportTasksSQL = `SELECT t.id           AS "ID",
+       t.title        AS "Título",
+       t.description  AS "Descrição",
+       p.name         AS "Projeto",
+       u.name         AS "Responsável",
+       t.status       AS "Status",
+       t.priority     AS "Prioridade",
+       t.due_date     AS "Prazo",
+       t.completed_at AS "Concluída em",
+       t.created_at   AS "Criada em"
+FROM tasks t
+         LEFT JOIN projects p ON p.id = t.project_id
+         LEFT JOIN users u ON u.id = t.assigned_to
+WHERE ($1::bigint IS NULL OR t.project_id = $1)
+  AND ($2::bigint IS NULL OR t.assigned_to = $2)
+  AND ($3::text IS NULL OR t.status = $3)
+  AND ($4::text IS NULL OR t.priority = $4)
+ORDER BY t.id`
+
+	exportProjectsSQL = `SELECT p.id          AS "ID",
+       p.name        AS "Nome",
+       p.description AS "Descrição",
+       c.name        AS "Cliente",
+       p.status      AS "Status",
+       p.start_date  AS "Início",
+       p.end_date    AS "Término",
+       p.created_at  AS "Criado em"
+FROM projects p
+         LEFT JOIN clients c ON c.id = p.client_id
+WHERE ($1::bigint IS NULL OR p.client_id = $1)
+  AND ($2::bigint IS NULL OR EXISTS (SELECT 1 FROM project_user pu WHERE pu.project_id = p.id AND pu.user_id = $2))
+ORDER BY p.id`
+
+	exportShipmentsSQL = `SELECT *
+FROM shipments
+WHERE ($1::varchar IS NULL OR shipment_id = $1)
+ORDER BY shipment_id`
+)
+
+// dataset é a consulta de uma exportação com os filtros já convertidos em parâmetros
+type dataset struct {
+	sql  string
+	args []any
+}
+
+// datasetFor monta a consulta do recurso exportado
+func datasetFor(resource string, filters exportTypes.Filters) (dataset, error) {
+	switch resource {
+	case exportTypes.Tasks:
+		return dataset{sql: exportTasksSQL, args: []any{
+			optionalID(filters.ProjectID),
+			optionalID(filters.AssignedTo),
+			optionalText(filters.Status),
+			optionalText(filters.Priority),
+		}}, nil
+	case exportTypes.Projects:
+		return dataset{sql: exportProjectsSQL, args: []any{
+			optionalID(filters.ClientID),
+			optionalID(filters.UserID),
+		}}, nil
+	case exportTypes.Shipments:
+		return dataset{sql: exportShipmentsSQL, args: []any{
+			optionalText(filters.ShipmentID),
+		}}, nil
+	default:
+		return dataset{}, fmt.Errorf("%w: recurso %q não pode ser exportado", ErrInvalidExport, resource)
+	}
+}
+
+// stream executa a consulta e grava as linhas na planilha à medida que chegam do banco,
+// sem carregar o resultado em memória. Retorna a quantidade de linhas gravadas.
+func (d dataset) stream(ctx context.Context, out sheetWriter) (int, error) {
+	conn, err := database.AcquireConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, d.sql, d.args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	columns := make([]column, len(fields))
+	for i, field := range fields {
+		columns[i] = column{Name: field.Name, OID: field.DataTypeOID}
+	}
+	if err := out.WriteHeader(columns); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return count, err
+		}
+		if err := out.WriteRow(values); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// optionalID converte um ID de filtro em parâmetro, NULL quando não informado
+func optionalID(id int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: id, Valid: id > 0}
+}
+
+// optionalText converte um texto de filtro em parâmetro, NULL quando não informado
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
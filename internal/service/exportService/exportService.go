@@ -0,0 +1,229 @@
+package exportService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/queue"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/entity/exportEntity"
+	"sixTask/internal/repository/exportRepository"
+	"sixTask/internal/repository/notificationRepository"
+	"sixTask/internal/types/exportStatusTypes"
+	"sixTask/internal/types/exportTypes"
+	"sixTask/internal/types/morphTypes"
+)
+
+// notificationType é o tipo das notificações enviadas ao fim da exportação
+const notificationType = "export"
+
+var (
+	// ErrFileUnavailable indica que a exportação ainda não gerou arquivo
+	ErrFileUnavailable = errors.New("arquivo da exportação indisponível")
+	// ErrInvalidExport indica uma exportação com recurso, formato ou filtros inválidos
+	ErrInvalidExport = errors.New("exportação inválida")
+)
+
+// ExportPayload é o conteúdo da tarefa de exportação
+type ExportPayload struct {
+	queue.Requester
+	ExportID int64 `json:"export_id"`
+}
+
+// ExportJob é o job de geração de exportação processado pelo worker, na mesma fila das
+// importações de planilhas
+var ExportJob = queue.Job[ExportPayload]{
+	Name:      "export:generate",
+	Queue:     "planilhas",
+	MaxRetry:  3,
+	Timeout:   30 * time.Minute,
+	Retention: queue.ResultRetention,
+}
+
+// EnqueueExport enfileira o processamento da exportação na fila de planilhas
+func EnqueueExport(ctx context.Context, id, requestedBy int64) error {
+	_, err := ExportJob.Enqueue(ctx, ExportPayload{
+		Requester: queue.Requester{RequestedBy: requestedBy},
+		ExportID:  id,
+	})
+	return err
+}
+
+// Start registra a exportação e enfileira a geração do arquivo
+func Start(ctx context.Context, params database.CreateExportParams) (database.Export, error) {
+	export, err := exportRepository.CreateExport(ctx, params)
+	if err != nil {
+		return database.Export{}, err
+	}
+
+	// Se a fila estiver indisponível a exportação continua pendente e pode ser reenfileirada depois
+	if err := EnqueueExport(ctx, export.ID, export.UserID); err != nil {
+		slog.ErrorContext(ctx, "Erro ao enfileirar exportação", "export_id", export.ID, "error", err)
+	}
+
+	return export, nil
+}
+
+// Process gera o arquivo da exportação: percorre as linhas da consulta gravando-as em um
+// arquivo temporário, envia o arquivo ao storage e notifica o solicitante com o link de
+// download. Em caso de falha o solicitante só é notificado na última tentativa.
+func Process(ctx context.Context, id int64) error {
+	export, err := exportRepository.StartExport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	filename, key, rows, err := run(ctx, export)
+	if err != nil {
+		message := err.Error()
+		if _, finishErr := exportRepository.FinishExport(context.Background(), database.FinishExportParams{
+			Status:       exportStatusTypes.Failed,
+			ErrorMessage: pgtype.Text{String: message, Valid: true},
+			ID:           export.ID,
+		}); finishErr != nil {
+			slog.ErrorContext(ctx, "Erro ao finalizar exportação", "export_id", export.ID, "error", finishErr)
+		}
+
+		skip := errors.Is(err, ErrInvalidExport) || errors.Is(err, errTooManyRows)
+		if skip || isLastAttempt(ctx) {
+			notify(export, "Falha na exportação",
+				fmt.Sprintf("Não foi possível gerar a exportação de %s: %s", resourceLabel(export.Resource), message))
+		}
+		if skip {
+			return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+		}
+		return err
+	}
+
+	finished, err := exportRepository.FinishExport(context.Background(), database.FinishExportParams{
+		Status:   exportStatusTypes.Completed,
+		Filename: pgtype.Text{String: filename, Valid: true},
+		Filepath: pgtype.Text{String: key, Valid: true},
+		RowCount: int32(rows),
+		ID:       export.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := queue.SetResult(ctx, "exportação concluída", exportEntity.FromDatabaseExport(finished)); err != nil {
+		slog.WarnContext(ctx, "Erro ao gravar resultado do job da exportação", "export_id", export.ID, "error", err)
+	}
+
+	notify(export, "Exportação concluída",
+		fmt.Sprintf("A exportação de %s está pronta (%d linhas): %s", resourceLabel(export.Resource), rows, DownloadLink(export.ID)))
+
+	return nil
+}
+
+// run gera o arquivo e o grava no storage, devolvendo o nome do arquivo, a chave e a quantidade de linhas
+func run(ctx context.Context, export database.Export) (string, string, int, error) {
+	var filters exportTypes.Filters
+	if err := json.Unmarshal(export.Filters, &filters); err != nil {
+		return "", "", 0, fmt.Errorf("%w: filtros inválidos: %v", ErrInvalidExport, err)
+	}
+
+	query, err := datasetFor(export.Resource, filters)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	out, err := newSheetWriter(export.Format, resourceLabel(export.Resource))
+	if err != nil {
+		return "", "", 0, err
+	}
+	defer out.Close()
+
+	rows, err := query.stream(ctx, out)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	file, size, err := out.Finish()
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", export.Resource, time.Now().Format("20060102-150405"), export.Format)
+	key := FileKey(export, filename)
+	if err := store.Put(ctx, key, file, size, out.ContentType()); err != nil {
+		return "", "", 0, fmt.Errorf("erro ao gravar arquivo da exportação: %w", err)
+	}
+
+	return filename, key, rows, nil
+}
+
+// FileKey é a chave do arquivo da exportação no storage
+func FileKey(export database.Export, filename string) string {
+	return path.Join("exports", strconv.FormatInt(export.UserID, 10), strconv.FormatInt(export.ID, 10), filename)
+}
+
+// File retorna a chave e o nome do arquivo de uma exportação concluída
+func File(export database.Export) (string, string, error) {
+	if export.Status != exportStatusTypes.Completed || !export.Filepath.Valid || export.Filepath.String == "" {
+		return "", "", ErrFileUnavailable
+	}
+
+	return export.Filepath.String, export.Filename.String, nil
+}
+
+// DownloadLink monta o link de download enviado na notificação, a partir de APP_URL
+func DownloadLink(id int64) string {
+	return appConfig.Get().App.URL + exportEntity.DownloadPath(id)
+}
+
+// notify registra a notificação da exportação para o solicitante, apenas registrando falhas
+func notify(export database.Export, title, content string) {
+	_, err := notificationRepository.CreateNotification(context.Background(), database.CreateNotificationParams{
+		UserID:         pgtype.Int8{Int64: export.UserID, Valid: true},
+		Title:          title,
+		Content:        content,
+		Type:           notificationType,
+		NotifiableType: morphTypes.Export,
+		NotifiableID:   export.ID,
+	})
+	if err != nil {
+		slog.Error("Erro ao notificar exportação", "export_id", export.ID, "error", err)
+	}
+}
+
+// isLastAttempt indica se a execução atual do worker é a última tentativa da tarefa
+func isLastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return true
+	}
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+	return retried >= maxRetry
+}
+
+// resourceLabel é o nome do recurso exportado usado no arquivo e nas notificações
+func resourceLabel(resource string) string {
+	switch resource {
+	case exportTypes.Tasks:
+		return "tarefas"
+	case exportTypes.Projects:
+		return "projetos"
+	case exportTypes.Shipments:
+		return "embarques"
+	default:
+		return resource
+	}
+}
@@ -0,0 +1,289 @@
+package exportService
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+
+	"sixTask/internal/types/exportTypes"
+)
+
+// maxSheetName é o tamanho máximo do nome de uma aba no Excel
+const maxSheetName = 31
+
+// errTooManyRows indica que o resultado não cabe em uma aba do Excel
+var errTooManyRows = fmt.Errorf("%w: o resultado excede o limite de %d linhas do Excel, exporte em CSV ou aplique filtros",
+	ErrInvalidExport, excelize.TotalRows-1)
+
+// column é uma coluna do resultado da consulta, com o tipo do Postgres
+type column struct {
+	Name string
+	OID  uint32
+}
+
+// sheetWriter grava as linhas da exportação em um arquivo temporário
+type sheetWriter interface {
+	WriteHeader(columns []column) error
+	WriteRow(values []any) error
+	// Finish conclui o arquivo e o devolve posicionado no início, com o tamanho em bytes
+	Finish() (io.Reader, int64, error)
+	ContentType() string
+	// Close descarta o arquivo temporário
+	Close()
+}
+
+// newSheetWriter cria o gravador do formato da exportação; title é o nome da aba no XLSX
+func newSheetWriter(format, title string) (sheetWriter, error) {
+	switch format {
+	case exportTypes.XLSX:
+		return newXLSXWriter(title)
+	case exportTypes.CSV:
+		return newCSVWriter()
+	default:
+		return nil, fmt.Errorf("%w: formato %q não suportado", ErrInvalidExport, format)
+	}
+}
+
+// xlsxWriter grava a planilha com o StreamWriter do excelize, que mantém em memória
+// apenas a linha atual. Datas recebem formato de data para que o Excel as reconheça.
+type xlsxWriter struct {
+	file     *excelize.File
+	stream   *excelize.StreamWriter
+	tmp      *os.File
+	columns  []column
+	row      int
+	header   int
+	date     int
+	datetime int
+}
+
+func newXLSXWriter(title string) (*xlsxWriter, error) {
+	if len([]rune(title)) > maxSheetName {
+		title = string([]rune(title)[:maxSheetName])
+	}
+
+	file := excelize.NewFile()
+	w := &xlsxWriter{file: file}
+
+	if err := file.SetSheetName(file.GetSheetName(0), title); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	var err error
+	if w.header, err = file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}}); err != nil {
+		w.Close()
+		return nil, err
+	}
+	// Formatos internos do Excel: 14 = data curta e 22 = data e hora
+	if w.date, err = file.NewStyle(&excelize.Style{NumFmt: 14}); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if w.datetime, err = file.NewStyle(&excelize.Style{NumFmt: 22}); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	if w.stream, err = file.NewStreamWriter(title); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *xlsxWriter) WriteHeader(columns []column) error {
+	w.columns = columns
+	if len(columns) == 0 {
+		return nil
+	}
+
+	if err := w.stream.SetColWidth(1, len(columns), 18); err != nil {
+		return err
+	}
+
+	cells := make([]any, len(columns))
+	for i, col := range columns {
+		cells[i] = excelize.Cell{StyleID: w.header, Value: col.Name}
+	}
+
+	w.row = 1
+	return w.stream.SetRow("A1", cells, excelize.RowOpts{StyleID: w.header})
+}
+
+func (w *xlsxWriter) WriteRow(values []any) error {
+	if w.row >= excelize.TotalRows {
+		return errTooManyRows
+	}
+	w.row++
+
+	cells := make([]any, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case time.Time:
+			style := w.datetime
+			if i < len(w.columns) && w.columns[i].OID == pgtype.DateOID {
+				style = w.date
+			}
+			cells[i] = excelize.Cell{StyleID: style, Value: v}
+		case pgtype.Numeric:
+			cells[i] = numericValue(v)
+		default:
+			cells[i] = v
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, cells)
+}
+
+func (w *xlsxWriter) Finish() (io.Reader, int64, error) {
+	if err := w.stream.Flush(); err != nil {
+		return nil, 0, err
+	}
+
+	tmp, err := os.CreateTemp("", "export-*.xlsx")
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao criar arquivo da exportação: %w", err)
+	}
+	w.tmp = tmp
+
+	size, err := w.file.WriteTo(tmp)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+
+	return tmp, size, nil
+}
+
+func (w *xlsxWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (w *xlsxWriter) Close() {
+	w.file.Close()
+	removeTemp(w.tmp)
+}
+
+// csvWriter grava o CSV diretamente no arquivo temporário. O BOM faz o Excel abrir
+// o arquivo como UTF-8; datas seguem o formato dd/mm/aaaa.
+type csvWriter struct {
+	tmp     *os.File
+	writer  *csv.Writer
+	columns []column
+}
+
+func newCSVWriter() (*csvWriter, error) {
+	tmp, err := os.CreateTemp("", "export-*.csv")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar arquivo da exportação: %w", err)
+	}
+
+	w := &csvWriter{tmp: tmp, writer: csv.NewWriter(tmp)}
+	if _, err := tmp.WriteString("\ufeff"); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *csvWriter) WriteHeader(columns []column) error {
+	w.columns = columns
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+
+	return w.writer.Write(names)
+}
+
+func (w *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			record[i] = ""
+		case time.Time:
+			if i < len(w.columns) && w.columns[i].OID == pgtype.DateOID {
+				record[i] = v.Format("02/01/2006")
+			} else {
+				record[i] = v.Format("02/01/2006 15:04:05")
+			}
+		case pgtype.Numeric:
+			record[i] = strconv.FormatFloat(numericValue(v), 'f', -1, 64)
+		case bool:
+			record[i] = map[bool]string{true: "sim", false: "não"}[v]
+		case string:
+			record[i] = v
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Finish() (io.Reader, int64, error) {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return nil, 0, err
+	}
+
+	size, err := w.tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := w.tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+
+	return w.tmp, size, nil
+}
+
+func (w *csvWriter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (w *csvWriter) Close() {
+	removeTemp(w.tmp)
+}
+
+// numericValue converte um NUMERIC do Postgres para float64; NULL e NaN viram zero
+func numericValue(n pgtype.Numeric) float64 {
+	value, err := n.Float64Value()
+	if err != nil || !value.Valid {
+		return 0
+	}
+
+	return value.Float64
+}
+
+// removeTemp fecha e remove o arquivo temporário, apenas registrando falhas
+func removeTemp(file *os.File) {
+	if file == nil {
+		return
+	}
+
+	file.Close()
+	if err := os.Remove(file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error("Erro ao remover arquivo temporário", "file", file.Name(), "error", err)
+	}
+}
@@ -0,0 +1,124 @@
+package healthService
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"sixTask/config/queue"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+)
+
+// CheckTimeout é o tempo máximo de cada verificação; acima dele a dependência é considerada indisponível
+const CheckTimeout = 2 * time.Second
+
+// Situações informadas por Ready
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check é a verificação de uma dependência
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// CheckResult é o resultado de uma verificação. O erro vai apenas para o log, para não
+// expor endereços e credenciais na resposta.
+type CheckResult struct {
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report reúne o resultado de todas as verificações
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// OK informa se todas as dependências estão disponíveis
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checks retorna as dependências exigidas pelo servidor e pelo worker: banco, Redis e storage
+func Checks() []Check {
+	return []Check{
+		{Name: "database", Run: pingDatabase},
+		{Name: "redis", Run: func(ctx context.Context) error { return queue.Ping() }},
+		{Name: "storage", Run: pingStorage},
+	}
+}
+
+// Ready executa as verificações em paralelo, cada uma limitada a CheckTimeout
+func Ready(ctx context.Context, checks []Check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := run(ctx, check)
+			result := CheckResult{Status: StatusOK, DurationMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = StatusUnavailable
+				slog.WarnContext(ctx, "Dependência indisponível", "check", check.Name, "error", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if err != nil {
+				report.Status = StatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run executa a verificação respeitando CheckTimeout mesmo quando ela não aceita contexto
+func run(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- check.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func pingDatabase(ctx context.Context) error {
+	pool, err := database.GetPool()
+	if err != nil {
+		return err
+	}
+
+	return pool.Ping(ctx)
+}
+
+func pingStorage(ctx context.Context) error {
+	store, err := storageProvider.Default()
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return errors.New("storage não configurado")
+	}
+
+	return store.Ping(ctx)
+}
@@ -0,0 +1,212 @@
+package importService
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	errInvalidNumber  = errors.New("número inválido")
+	errInvalidInteger = errors.New("número inteiro inválido")
+	errInvalidDate    = errors.New("data inválida")
+	errInvalidBoolean = errors.New("valor lógico inválido")
+	errInvalidFormat  = errors.New("formato de data inválido, use dd, mm, aaaa, aa, hh, mi e ss (ex.: dd/mm/aaaa)")
+)
+
+// dateLayouts são os formatos de data aceitos em células de texto quando o template
+// não informa o formato, além do número de série do Excel
+var dateLayouts = []string{
+	"02/01/2006",
+	"02/01/2006 15:04",
+	"02/01/2006 15:04:05",
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"02-01-2006",
+	"02.01.2006",
+}
+
+// formatTokens traduz o formato do template para o layout do pacote time
+var formatTokens = strings.NewReplacer(
+	"aaaa", "2006",
+	"aa", "06",
+	"dd", "02",
+	"mm", "01",
+	"hh", "15",
+	"mi", "04",
+	"ss", "05",
+)
+
+// Valores lógicos aceitos quando o template não informa verdadeiro e falso
+var (
+	defaultTrue  = []string{"sim", "s", "true", "t", "verdadeiro", "1", "x"}
+	defaultFalse = []string{"não", "nao", "n", "false", "f", "falso", "0"}
+)
+
+// convert transforma o valor da célula no tipo da coluna de destino (data_type do
+// information_schema), aplicando as regras do template. Células vazias viram NULL;
+// tipos não tratados seguem como texto e são convertidos pelo Postgres.
+func convert(col column, raw string) (any, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return nil, nil
+	}
+
+	switch col.DataType {
+	case "smallint", "integer", "bigint":
+		return parseInteger(value, col.Decimal)
+	case "numeric":
+		number, err := normalizeNumber(value, col.Decimal)
+		if err != nil {
+			return nil, err
+		}
+		var numeric pgtype.Numeric
+		if err := numeric.Scan(number); err != nil {
+			return nil, errInvalidNumber
+		}
+		return numeric, nil
+	case "real", "double precision":
+		number, err := normalizeNumber(value, col.Decimal)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(number, 64)
+	case "date", "timestamp without time zone", "timestamp with time zone":
+		return parseDate(value, col.Layout)
+	case "boolean":
+		return parseBoolean(value, col.Verdadeiro, col.Falso)
+	default:
+		return value, nil
+	}
+}
+
+// isDateType indica se o tipo da coluna recebe datas
+func isDateType(dataType string) bool {
+	return dataType == "date" || strings.HasPrefix(dataType, "timestamp")
+}
+
+// isNumericType indica se o tipo da coluna recebe números
+func isNumericType(dataType string) bool {
+	switch dataType {
+	case "smallint", "integer", "bigint", "numeric", "real", "double precision":
+		return true
+	default:
+		return false
+	}
+}
+
+// dateLayout converte o formato do template (ex.: dd/mm/aaaa) para o layout do pacote time.
+// O formato precisa conter pelo menos dia, mês e ano.
+func dateLayout(format string) (string, error) {
+	layout := formatTokens.Replace(format)
+
+	reference := time.Date(2021, time.November, 23, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(layout, reference.Format(layout))
+	if err != nil || !parsed.Equal(reference) {
+		return "", errInvalidFormat
+	}
+
+	return layout, nil
+}
+
+// normalizeNumber devolve o número com ponto decimal. Com decimal "," o ponto é tratado como
+// separador de milhar (1.234,56) e vice-versa; vazio aceita os dois formatos, considerando
+// decimal o último separador encontrado.
+func normalizeNumber(value, decimal string) (string, error) {
+	value = strings.NewReplacer(" ", "", "R$", "").Replace(value)
+
+	switch decimal {
+	case ",":
+		value = strings.Replace(strings.ReplaceAll(value, ".", ""), ",", ".", 1)
+	case ".":
+		value = strings.ReplaceAll(value, ",", "")
+	default:
+		comma := strings.LastIndex(value, ",")
+		dot := strings.LastIndex(value, ".")
+		switch {
+		case comma >= 0 && dot >= 0 && comma > dot:
+			value = strings.ReplaceAll(value, ".", "")
+			value = strings.Replace(value, ",", ".", 1)
+		case comma >= 0 && dot >= 0:
+			value = strings.ReplaceAll(value, ",", "")
+		case comma >= 0:
+			value = strings.Replace(value, ",", ".", 1)
+		}
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return "", errInvalidNumber
+	}
+
+	return value, nil
+}
+
+// parseInteger aceita inteiros gravados como número decimal pelo Excel (ex.: 42.0)
+func parseInteger(value, decimal string) (int64, error) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+
+	number, err := normalizeNumber(value, decimal)
+	if err != nil {
+		return 0, errInvalidInteger
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return 0, errInvalidInteger
+	}
+
+	return int64(f), nil
+}
+
+// parseDate aceita o número de série do Excel ou, em células de texto, o layout do
+// template (quando informado) ou um dos formatos de dateLayouts
+func parseDate(value, layout string) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return time.Time{}, errInvalidDate
+		}
+		return t, nil
+	}
+
+	layouts := dateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errInvalidDate
+}
+
+// parseBoolean compara o valor, sem diferenciar maiúsculas, com os valores de verdadeiro
+// e falso do template ou, se não informados, com sim/não, true/false e 1/0
+func parseBoolean(value string, truthy, falsy []string) (bool, error) {
+	if len(truthy) == 0 {
+		truthy, falsy = defaultTrue, defaultFalse
+	}
+
+	for _, candidate := range truthy {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true, nil
+		}
+	}
+	for _, candidate := range falsy {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return false, nil
+		}
+	}
+
+	return false, errInvalidBoolean
+}
@@ -0,0 +1,256 @@
+package importService
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		decimal string
+		want    string
+		wantErr bool
+	}{
+		{"vírgula decimal com milhar", "1.234,56", ",", "1234.56", false},
+		{"vírgula decimal sem milhar", "12,5", ",", "12.5", false},
+		{"vírgula decimal com ponto de milhar apenas", "1.234", ",", "1234", false},
+		{"ponto decimal com milhar", "1,234.56", ".", "1234.56", false},
+		{"ponto decimal com vírgula de milhar apenas", "1,234", ".", "1234", false},
+		{"moeda e espaços", "R$ 1.234,56", ",", "1234.56", false},
+		{"automático com vírgula decimal", "1.234,56", "", "1234.56", false},
+		{"automático com ponto decimal", "1,234.56", "", "1234.56", false},
+		{"automático só com vírgula", "12,5", "", "12.5", false},
+		{"automático só com ponto", "12.5", "", "12.5", false},
+		{"negativo", "-1.234,5", ",", "-1234.5", false},
+		{"texto", "doze", "", "", true},
+		{"dois decimais", "1,2,3", ",", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeNumber(tt.value, tt.decimal)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidNumber) {
+					t.Fatalf("normalizeNumber(%q, %q) erro = %v, esperado errInvalidNumber", tt.value, tt.decimal, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeNumber(%q, %q) erro inesperado: %v", tt.value, tt.decimal, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeNumber(%q, %q) = %q, esperado %q", tt.value, tt.decimal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInteger(t *testing.T) {
+	tests := []struct {
+		value   string
+		decimal string
+		want    int64
+		wantErr bool
+	}{
+		{"42", "", 42, false},
+		{"-7", "", -7, false},
+		{"42.0", "", 42, false},
+		{"42,0", ",", 42, false},
+		{"1.000", ",", 1000, false},
+		{"1,000", ".", 1000, false},
+		{"42.5", "", 0, true},
+		{"1e30", "", 0, true},
+		{"quarenta", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseInteger(tt.value, tt.decimal)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidInteger) {
+					t.Fatalf("parseInteger(%q) erro = %v, esperado errInvalidInteger", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseInteger(%q) erro inesperado: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseInteger(%q) = %d, esperado %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateLayout(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"dd/mm/aaaa", "02/01/2006", false},
+		{"mm/dd/aaaa", "01/02/2006", false},
+		{"dd/mm/aa", "02/01/06", false},
+		{"aaaa-mm-dd hh:mi:ss", "2006-01-02 15:04:05", false},
+		{"dd.mm.aaaa hh:mi", "02.01.2006 15:04", false},
+		{"dd/mm", "", true},
+		{"mm/aaaa", "", true},
+		{"", "", true},
+		{"data", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := dateLayout(tt.format)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidFormat) {
+					t.Fatalf("dateLayout(%q) erro = %v, esperado errInvalidFormat", tt.format, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dateLayout(%q) erro inesperado: %v", tt.format, err)
+			}
+			if got != tt.want {
+				t.Errorf("dateLayout(%q) = %q, esperado %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	day := time.Date(2021, time.November, 23, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		layout  string
+		want    time.Time
+		wantErr bool
+	}{
+		{"dd/mm/aaaa", "23/11/2021", "", day, false},
+		{"com horário", "23/11/2021 14:30", "", day.Add(14*time.Hour + 30*time.Minute), false},
+		{"ISO", "2021-11-23", "", day, false},
+		{"RFC 3339", "2021-11-23T00:00:00Z", "", day, false},
+		{"com hífen", "23-11-2021", "", day, false},
+		{"com ponto", "23.11.2021", "", day, false},
+		{"número de série do Excel", "44523", "", day, false},
+		{"número de série com horário", "44523.5", "", day.Add(12 * time.Hour), false},
+		{"formato do template", "11/23/2021", "01/02/2006", day, false},
+		{"formato do template substitui os padrões", "23/11/2021", "01/02/2006", time.Time{}, true},
+		{"formato americano sem template", "11/23/2021", "", time.Time{}, true},
+		{"dia inexistente", "31/02/2021", "", time.Time{}, true},
+		{"texto", "ontem", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDate(tt.value, tt.layout)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidDate) {
+					t.Fatalf("parseDate(%q) erro = %v, esperado errInvalidDate", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDate(%q) erro inesperado: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, esperado %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBoolean(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		truthy  []string
+		falsy   []string
+		want    bool
+		wantErr bool
+	}{
+		{"sim", "Sim", nil, nil, true, false},
+		{"true", "TRUE", nil, nil, true, false},
+		{"x", "x", nil, nil, true, false},
+		{"1", "1", nil, nil, true, false},
+		{"não com acento", "NÃO", nil, nil, false, false},
+		{"nao sem acento", "nao", nil, nil, false, false},
+		{"0", "0", nil, nil, false, false},
+		{"valor desconhecido", "talvez", nil, nil, false, true},
+		{"verdadeiro do template", "ativo", []string{"Ativo"}, []string{"Inativo"}, true, false},
+		{"falso do template", "INATIVO", []string{"Ativo"}, []string{"Inativo"}, false, false},
+		{"template com espaços", "ativo", []string{" Ativo "}, []string{"Inativo"}, true, false},
+		{"template substitui os padrões", "sim", []string{"Ativo"}, []string{"Inativo"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBoolean(tt.value, tt.truthy, tt.falsy)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidBoolean) {
+					t.Fatalf("parseBoolean(%q) erro = %v, esperado errInvalidBoolean", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBoolean(%q) erro inesperado: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseBoolean(%q) = %v, esperado %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		col     column
+		raw     string
+		check   func(any) bool
+		wantErr error
+	}{
+		{"célula vazia vira NULL", column{DataType: "integer"}, "   ", func(v any) bool { return v == nil }, nil},
+		{"inteiro", column{DataType: "bigint"}, " 42 ", func(v any) bool { return v == int64(42) }, nil},
+		{"inteiro inválido", column{DataType: "integer"}, "4,2", nil, errInvalidInteger},
+		{"numeric", column{DataType: "numeric", Decimal: ","}, "1.234,56", func(v any) bool {
+			n, ok := v.(pgtype.Numeric)
+			f, _ := n.Float64Value()
+			return ok && n.Valid && f.Float64 == 1234.56
+		}, nil},
+		{"numeric inválido", column{DataType: "numeric"}, "abc", nil, errInvalidNumber},
+		{"double precision", column{DataType: "double precision"}, "2,5", func(v any) bool { return v == 2.5 }, nil},
+		{"data", column{DataType: "date", Layout: "02/01/2006"}, "23/11/2021", func(v any) bool {
+			return v.(time.Time).Equal(time.Date(2021, time.November, 23, 0, 0, 0, 0, time.UTC))
+		}, nil},
+		{"data inválida", column{DataType: "timestamp with time zone"}, "ontem", nil, errInvalidDate},
+		{"lógico", column{DataType: "boolean"}, "sim", func(v any) bool { return v == true }, nil},
+		{"lógico inválido", column{DataType: "boolean"}, "talvez", nil, errInvalidBoolean},
+		{"texto segue como está", column{DataType: "character varying"}, " São Paulo ", func(v any) bool { return v == "São Paulo" }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert(tt.col, tt.raw)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("convert(%q) erro = %v, esperado %v", tt.raw, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convert(%q) erro inesperado: %v", tt.raw, err)
+			}
+			if !tt.check(got) {
+				t.Errorf("convert(%q) = %#v", tt.raw, got)
+			}
+		})
+	}
+}
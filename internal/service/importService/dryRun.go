@@ -0,0 +1,104 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"sixTask/config/appConfig"
+	"sixTask/internal/database"
+)
+
+// maxDryRunErrors é a quantidade máxima de erros devolvidos na simulação; os demais só são contados
+const maxDryRunErrors = 100
+
+// errSampleLimit interrompe a leitura da planilha ao atingir o limite da amostra
+var errSampleLimit = errors.New("limite da amostra atingido")
+
+// RowError descreve o erro de uma linha da planilha
+type RowError struct {
+	Linha  int    `json:"linha"`
+	Coluna string `json:"coluna"`
+	Valor  string `json:"valor"`
+	Erro   string `json:"erro"`
+}
+
+// DryRunResult é o resultado da simulação de um template com uma planilha de exemplo
+type DryRunResult struct {
+	TotalRows    int `json:"total_rows"`
+	InsertedRows int `json:"inserted_rows"`
+	UpdatedRows  int `json:"updated_rows"`
+	FailedRows   int `json:"failed_rows"`
+	// Truncated indica que apenas as primeiras IMPORT_DRY_RUN_ROWS linhas foram simuladas
+	Truncated bool       `json:"truncated"`
+	Errors    []RowError `json:"errors"`
+}
+
+// errorList guarda em memória os primeiros erros da simulação
+type errorList struct {
+	items []RowError
+}
+
+func (l *errorList) Add(line int, column, value, message string) error {
+	if len(l.items) < maxDryRunErrors {
+		l.items = append(l.items, RowError{Linha: line, Coluna: column, Valor: value, Erro: message})
+	}
+
+	return nil
+}
+
+// DryRun simula a importação da planilha com o template: converte os valores e executa
+// o upsert em transações desfeitas ao final, de modo que erros do banco (ex.: texto maior
+// que a coluna) também aparecem. Nada é gravado na tabela de destino.
+func DryRun(ctx context.Context, template database.Template, filename string, reader io.Reader) (DryRunResult, error) {
+	format, err := formatOf(filename)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	m, err := loadMapping(ctx, template)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	var res result
+	errs := &errorList{}
+	runner := &importer{
+		mapping: m,
+		report:  errs,
+		result:  &res,
+		size:    batchSize(),
+		dryRun:  true,
+	}
+
+	limit := dryRunRows()
+	add := runner.add(ctx)
+	truncated := false
+	err = readRows(reader, format, func(line int, cells []string) error {
+		if res.Total >= limit {
+			truncated = true
+			return errSampleLimit
+		}
+		return add(line, cells)
+	})
+	if err != nil && !errors.Is(err, errSampleLimit) {
+		return DryRunResult{}, err
+	}
+	if err := runner.flush(ctx); err != nil {
+		return DryRunResult{}, err
+	}
+
+	return DryRunResult{
+		TotalRows:    res.Total,
+		InsertedRows: res.Inserted,
+		UpdatedRows:  res.Updated,
+		FailedRows:   res.Failed,
+		Truncated:    truncated,
+		Errors:       append([]RowError{}, errs.items...),
+	}, nil
+}
+
+// dryRunRows é a quantidade máxima de linhas simuladas (IMPORT_DRY_RUN_ROWS, padrão 1000)
+func dryRunRows() int {
+	return appConfig.Get().Import.DryRunRows
+}
@@ -0,0 +1,300 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"sixTask/config/appConfig"
+	"sixTask/config/queue"
+	"sixTask/config/storageProvider"
+	"sixTask/internal/database"
+	"sixTask/internal/http/request/importRequest"
+	"sixTask/internal/repository/importRepository"
+	"sixTask/internal/repository/templateRepository"
+	"sixTask/internal/types/importStatusTypes"
+)
+
+var (
+	// ErrTemplateNotFound indica que o template informado não existe
+	ErrTemplateNotFound = errors.New("template não encontrado")
+	// ErrUnsupportedFile indica uma planilha que não é XLSX nem CSV
+	ErrUnsupportedFile = errors.New("formato de planilha não suportado, envie um arquivo .xlsx ou .csv")
+	// ErrReportUnavailable indica que a importação não gerou relatório de erros
+	ErrReportUnavailable = errors.New("relatório de erros indisponível")
+)
+
+// ImportPayload é o conteúdo da tarefa de importação
+type ImportPayload struct {
+	queue.Requester
+	ImportID int64 `json:"import_id"`
+}
+
+// ImportJob é o job de importação de planilha processado pelo worker. Planilhas grandes
+// podem levar vários minutos.
+var ImportJob = queue.Job[ImportPayload]{
+	Name:      "spreadsheet:import",
+	Queue:     "planilhas",
+	MaxRetry:  3,
+	Timeout:   time.Hour,
+	Retention: queue.ResultRetention,
+}
+
+// EnqueueImport enfileira o processamento da importação na fila de planilhas
+func EnqueueImport(ctx context.Context, id, requestedBy int64) error {
+	_, err := ImportJob.Enqueue(ctx, ImportPayload{
+		Requester: queue.Requester{RequestedBy: requestedBy},
+		ImportID:  id,
+	})
+	return err
+}
+
+// Policy retorna a política de upload das planilhas (IMPORT_MAX_SIZE, padrão 50MB).
+// Arquivos CSV costumam ser identificados como texto simples.
+func Policy() storageProvider.UploadPolicy {
+	return storageProvider.UploadPolicy{
+		MaxSize: appConfig.Get().Import.MaxSize,
+		AllowedTypes: []string{
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"text/csv",
+			"text/plain",
+		},
+	}
+}
+
+// Start grava a planilha no storage, registra a importação e enfileira o processamento.
+// O template é validado antes do upload para que erros de configuração apareçam na hora.
+func Start(ctx context.Context, request importRequest.CreateImportRequest, actorID int64) (database.Import, error) {
+	if _, err := formatOf(request.Filename()); err != nil {
+		return database.Import{}, err
+	}
+
+	template, err := templateRepository.GetTemplate(ctx, request.TemplateID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.Import{}, ErrTemplateNotFound
+	}
+	if err != nil {
+		return database.Import{}, err
+	}
+	if _, err := loadMapping(ctx, template); err != nil {
+		return database.Import{}, err
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return database.Import{}, err
+	}
+
+	src, err := request.File.Open()
+	if err != nil {
+		return database.Import{}, err
+	}
+	defer src.Close()
+
+	filetype, err := Policy().Check(src, request.File.Size)
+	if err != nil {
+		return database.Import{}, err
+	}
+
+	key, err := storageProvider.NewKey(path.Join("imports", time.Now().Format("2006/01")), request.Filename())
+	if err != nil {
+		return database.Import{}, err
+	}
+	if err := store.Put(ctx, key, src, request.File.Size, filetype); err != nil {
+		return database.Import{}, fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+
+	params := request.ToCreateImportParams(actorID, key).(database.CreateImportParams)
+	imp, err := importRepository.CreateImport(ctx, params)
+	if err != nil {
+		removeFile(store, key)
+		return database.Import{}, err
+	}
+
+	// Se a fila estiver indisponível a importação continua pendente e pode ser reenfileirada depois
+	if err := EnqueueImport(ctx, imp.ID, actorID); err != nil {
+		slog.ErrorContext(ctx, "Erro ao enfileirar importação", "import_id", imp.ID, "error", err)
+	}
+
+	return imp, nil
+}
+
+// Process executa a importação: lê a planilha, converte os valores conforme os tipos da
+// tabela de destino e grava as linhas em lotes. Linhas com erro são registradas no relatório
+// e não interrompem a importação. Problemas na planilha ou no template marcam a importação
+// como falha sem nova tentativa; falhas de banco ou storage são devolvidas ao worker.
+func Process(ctx context.Context, id int64) error {
+	imp, err := importRepository.StartImport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := run(ctx, imp)
+	if err != nil {
+		result.Status = importStatusTypes.Failed
+		result.Message = err.Error()
+	}
+
+	finished, finishErr := importRepository.FinishImport(context.Background(), result.finishParams(imp.ID))
+	if finishErr != nil {
+		slog.ErrorContext(ctx, "Erro ao finalizar importação", "import_id", imp.ID, "error", finishErr)
+	} else if err == nil {
+		// Resultado consultado por /api/jobs/:id; a importação continua disponível em /api/imports/:id
+		if err := queue.SetResult(ctx, "importação concluída", finished); err != nil {
+			slog.WarnContext(ctx, "Erro ao gravar resultado do job da importação", "import_id", imp.ID, "error", err)
+		}
+	}
+
+	if errors.Is(err, ErrInvalidTemplate) || errors.Is(err, ErrUnsupportedFile) || errors.Is(err, ErrInvalidSpreadsheet) {
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	return err
+}
+
+// run processa a planilha da importação e devolve os contadores finais
+func run(ctx context.Context, imp database.Import) (result, error) {
+	res := result{Status: importStatusTypes.Completed}
+
+	if !imp.TemplateID.Valid {
+		return res, fmt.Errorf("%w: o template da importação foi removido", ErrInvalidTemplate)
+	}
+	template, err := templateRepository.GetTemplate(ctx, imp.TemplateID.Int64)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return res, fmt.Errorf("%w: o template da importação foi removido", ErrInvalidTemplate)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	m, err := loadMapping(ctx, template)
+	if err != nil {
+		return res, err
+	}
+
+	format, err := formatOf(imp.Filename)
+	if err != nil {
+		return res, err
+	}
+
+	store, err := storageProvider.Default()
+	if err != nil {
+		return res, err
+	}
+
+	file, err := store.Get(ctx, imp.Filepath)
+	if err != nil {
+		return res, fmt.Errorf("erro ao abrir planilha: %w", err)
+	}
+	defer file.Close()
+
+	report, err := newErrorReport()
+	if err != nil {
+		return res, err
+	}
+	defer report.Close()
+
+	runner := &importer{
+		id:      imp.ID,
+		mapping: m,
+		report:  report,
+		result:  &res,
+		size:    batchSize(),
+	}
+	if err := readRows(file, format, runner.add(ctx)); err != nil {
+		return res, err
+	}
+	if err := runner.flush(ctx); err != nil {
+		return res, err
+	}
+
+	if report.Len() > 0 {
+		key := ReportKey(imp.ID)
+		if err := report.Save(ctx, store, key); err != nil {
+			return res, err
+		}
+		res.ReportPath = key
+	}
+
+	return res, nil
+}
+
+// ReportKey é a chave do relatório de erros da importação no storage
+func ReportKey(id int64) string {
+	return path.Join("imports", strconv.FormatInt(id, 10), "erros.csv")
+}
+
+// Report retorna a chave do relatório de erros da importação, se houver
+func Report(imp database.Import) (string, error) {
+	if !imp.ReportPath.Valid || imp.ReportPath.String == "" {
+		return "", ErrReportUnavailable
+	}
+
+	return imp.ReportPath.String, nil
+}
+
+// result acumula os contadores de uma importação
+type result struct {
+	Status     string
+	Total      int
+	Inserted   int
+	Updated    int
+	Failed     int
+	ReportPath string
+	Message    string
+}
+
+func (r result) finishParams(id int64) database.FinishImportParams {
+	return database.FinishImportParams{
+		Status:       r.Status,
+		TotalRows:    int32(r.Total),
+		InsertedRows: int32(r.Inserted),
+		UpdatedRows:  int32(r.Updated),
+		FailedRows:   int32(r.Failed),
+		ReportPath:   pgtype.Text{String: r.ReportPath, Valid: r.ReportPath != ""},
+		ErrorMessage: pgtype.Text{String: r.Message, Valid: r.Message != ""},
+		ID:           id,
+	}
+}
+
+func (r result) progressParams(id int64) database.UpdateImportProgressParams {
+	return database.UpdateImportProgressParams{
+		TotalRows:    int32(r.Total),
+		InsertedRows: int32(r.Inserted),
+		UpdatedRows:  int32(r.Updated),
+		FailedRows:   int32(r.Failed),
+		ID:           id,
+	}
+}
+
+// batchSize é a quantidade de linhas gravadas por transação (IMPORT_BATCH_SIZE, padrão 1000)
+func batchSize() int {
+	return appConfig.Get().Import.BatchSize
+}
+
+// formatOf identifica o formato da planilha pela extensão do nome original
+func formatOf(filename string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case formatXLSX, formatCSV:
+		return ext, nil
+	default:
+		return "", ErrUnsupportedFile
+	}
+}
+
+// removeFile remove um arquivo do storage, apenas registrando falhas
+func removeFile(store storageProvider.Storage, key string) {
+	if err := store.Delete(context.Background(), key); err != nil {
+		slog.Error("Erro ao remover arquivo do storage", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,249 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"sixTask/internal/database"
+	"sixTask/internal/repository/importRepository"
+)
+
+// stagingTable é a tabela temporária que recebe cada lote via COPY antes do upsert
+const stagingTable = "import_staging"
+
+// errRollback desfaz a transação da simulação depois de executado o upsert
+var errRollback = errors.New("simulação: transação desfeita")
+
+// pendingRow é uma linha já convertida aguardando gravação
+type pendingRow struct {
+	line   int
+	values []any
+}
+
+// rowReporter registra os erros por linha: em arquivo na importação e em memória na simulação
+type rowReporter interface {
+	Add(line int, column, value, message string) error
+}
+
+// importer agrupa as linhas válidas em lotes e grava cada lote em uma transação.
+// Com dryRun as transações são desfeitas, apenas contando inserções, atualizações e erros.
+type importer struct {
+	id      int64
+	mapping mapping
+	report  rowReporter
+	result  *result
+	size    int
+	dryRun  bool
+
+	rows []pendingRow
+	keys map[string]bool
+}
+
+// add converte a linha da planilha e a acrescenta ao lote atual. Erros de conversão
+// vão para o relatório; o lote é gravado ao atingir o tamanho configurado.
+func (i *importer) add(ctx context.Context) func(line int, cells []string) error {
+	return func(line int, cells []string) error {
+		i.result.Total++
+
+		values := make([]any, len(i.mapping.Columns))
+		failed := false
+		for j, col := range i.mapping.Columns {
+			raw := ""
+			if col.Index < len(cells) {
+				raw = cells[col.Index]
+			}
+
+			value, err := convert(col, raw)
+			if err != nil {
+				failed = true
+				if err := i.report.Add(line, col.Name, raw, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			values[j] = value
+		}
+
+		key := ""
+		if idx := i.mapping.Columns[i.mapping.key].Index; idx < len(cells) {
+			key = strings.TrimSpace(cells[idx])
+		}
+		if !failed && key == "" {
+			failed = true
+			if err := i.report.Add(line, i.mapping.Identification, "", "coluna de identificação vazia"); err != nil {
+				return err
+			}
+		}
+
+		if failed {
+			i.result.Failed++
+			return nil
+		}
+
+		// A mesma identificação duas vezes no lote geraria dois registros; grava o lote
+		// atual antes, de modo que a última linha da planilha prevaleça
+		if i.keys[key] {
+			if err := i.flush(ctx); err != nil {
+				return err
+			}
+		}
+		if i.keys == nil {
+			i.keys = make(map[string]bool, i.size)
+		}
+
+		i.rows = append(i.rows, pendingRow{line: line, values: values})
+		i.keys[key] = true
+
+		if len(i.rows) >= i.size {
+			return i.flush(ctx)
+		}
+
+		return nil
+	}
+}
+
+// flush grava o lote atual. Se o banco rejeitar o lote (ex.: texto maior que a coluna),
+// as linhas são gravadas uma a uma para identificar e reportar apenas as que falharam.
+func (i *importer) flush(ctx context.Context) error {
+	if len(i.rows) == 0 {
+		return nil
+	}
+
+	rows := i.rows
+	i.rows = nil
+	i.keys = nil
+
+	inserted, err := upsert(ctx, i.mapping, rows, i.dryRun)
+	if err != nil && isRowError(err) {
+		for _, row := range rows {
+			n, err := upsert(ctx, i.mapping, []pendingRow{row}, i.dryRun)
+			if err != nil && isRowError(err) {
+				i.result.Failed++
+				column, message := describe(err)
+				if err := i.report.Add(row.line, column, "", message); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			i.result.Inserted += n
+			i.result.Updated += 1 - n
+		}
+	} else if err != nil {
+		return err
+	} else {
+		i.result.Inserted += inserted
+		i.result.Updated += len(rows) - inserted
+	}
+
+	if i.dryRun {
+		return nil
+	}
+
+	// O progresso é apenas informativo; a importação segue mesmo sem conseguir gravá-lo
+	if err := importRepository.UpdateImportProgress(ctx, i.result.progressParams(i.id)); err != nil {
+		slog.WarnContext(ctx, "Erro ao atualizar progresso da importação", "import_id", i.id, "error", err)
+	}
+
+	return nil
+}
+
+// upsert grava as linhas na tabela de destino: copia o lote para uma tabela temporária,
+// atualiza os registros que já existem pela coluna de identificação e insere os demais.
+// A tabela fica bloqueada para outras gravações durante o lote, evitando que duas
+// importações simultâneas insiram a mesma identificação. Retorna quantas linhas foram inseridas.
+// Com dryRun a transação é desfeita ao final, sem bloquear a tabela.
+func upsert(ctx context.Context, m mapping, rows []pendingRow, dryRun bool) (int, error) {
+	table := pgx.Identifier{m.Table}.Sanitize()
+	staging := pgx.Identifier{stagingTable}.Sanitize()
+	key := pgx.Identifier{m.Identification}.Sanitize()
+
+	names := m.Names()
+	columns := make([]string, len(names))
+	var assignments []string
+	for j, name := range names {
+		columns[j] = pgx.Identifier{name}.Sanitize()
+		if name != m.Identification {
+			assignments = append(assignments, fmt.Sprintf("%s = s.%s", columns[j], columns[j]))
+		}
+	}
+	columnList := strings.Join(columns, ", ")
+
+	inserted := 0
+	err := database.RunInPgxTx(ctx, func(tx pgx.Tx) error {
+		if !dryRun {
+			if _, err := tx.Exec(ctx, fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", table)); err != nil {
+				return err
+			}
+		}
+
+		createStaging := fmt.Sprintf("CREATE TEMP TABLE %s ON COMMIT DROP AS SELECT %s FROM %s WITH NO DATA", staging, columnList, table)
+		if _, err := tx.Exec(ctx, createStaging); err != nil {
+			return err
+		}
+
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{stagingTable}, names, pgx.CopyFromSlice(len(rows), func(j int) ([]any, error) {
+			return rows[j].values, nil
+		})); err != nil {
+			return err
+		}
+
+		if len(assignments) > 0 {
+			update := fmt.Sprintf("UPDATE %s AS t SET %s FROM %s AS s WHERE t.%s = s.%s",
+				table, strings.Join(assignments, ", "), staging, key, key)
+			if _, err := tx.Exec(ctx, update); err != nil {
+				return err
+			}
+		}
+
+		insert := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s AS s WHERE NOT EXISTS (SELECT 1 FROM %s AS t WHERE t.%s = s.%s)",
+			table, columnList, columnList, staging, table, key, key)
+		tag, err := tx.Exec(ctx, insert)
+		if err != nil {
+			return err
+		}
+		inserted = int(tag.RowsAffected())
+
+		if dryRun {
+			return errRollback
+		}
+
+		return nil
+	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
+
+	return inserted, err
+}
+
+// isRowError indica se a falha foi causada pelos dados do lote: apenas erros do Postgres das
+// classes 22 (dados inválidos) e 23 (restrições de integridade). Qualquer outra falha, como a
+// queda da conexão ou o fim do prazo do contexto, é devolvida ao job em vez de virar erro de linha.
+func isRowError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")
+}
+
+// describe extrai a coluna e a mensagem de um erro de gravação para o relatório
+func describe(err error) (string, string) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ColumnName, pgErr.Message
+	}
+
+	return "", err.Error()
+}
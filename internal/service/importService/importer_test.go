@@ -0,0 +1,42 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"sixTask/internal/database"
+)
+
+func TestIsRowError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"texto longo demais", &pgconn.PgError{Code: "22001"}, true},
+		{"valor inválido", &pgconn.PgError{Code: "22P02"}, true},
+		{"unicidade", &pgconn.PgError{Code: "23505"}, true},
+		{"not null embrulhado", fmt.Errorf("upsert: %w", &pgconn.PgError{Code: "23502"}), true},
+		{"tabela inexistente", &pgconn.PgError{Code: "42P01"}, false},
+		{"banco em manutenção", &pgconn.PgError{Code: "57P01"}, false},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, false},
+		{"conexão perdida", io.ErrUnexpectedEOF, false},
+		{"prazo do contexto", context.DeadlineExceeded, false},
+		{"cancelamento", fmt.Errorf("copy: %w", context.Canceled), false},
+		{"banco indisponível", database.ErrUnavailable, false},
+		{"erro qualquer", errors.New("falha"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRowError(tt.err); got != tt.want {
+				t.Errorf("isRowError(%v) = %v, esperado %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package importService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sixTask/config/appConfig"
+	"sixTask/internal/database"
+	"sixTask/internal/repository/templateRepository"
+	"sixTask/internal/types/templateTypes"
+)
+
+// ErrInvalidTemplate indica um template que não pode ser usado na importação
+var ErrInvalidTemplate = errors.New("template inválido")
+
+// column relaciona uma coluna da planilha (Index, começando em 0) a uma coluna da tabela,
+// com as regras de conversão do template
+type column struct {
+	Index    int
+	Name     string
+	DataType string
+	// Layout é o Formato do template convertido para o layout do pacote time
+	Layout     string
+	Decimal    string
+	Verdadeiro []string
+	Falso      []string
+}
+
+// mapping é o template já validado contra a estrutura da tabela de destino
+type mapping struct {
+	Table          string
+	Identification string
+	Columns        []column
+	// key é a posição da coluna de identificação em Columns
+	key int
+}
+
+// Names retorna os nomes das colunas da tabela, na ordem de Columns
+func (m mapping) Names() []string {
+	names := make([]string, len(m.Columns))
+	for i, col := range m.Columns {
+		names[i] = col.Name
+	}
+
+	return names
+}
+
+// loadMapping decodifica as colunas do template salvo e o valida com buildMapping
+func loadMapping(ctx context.Context, template database.Template) (mapping, error) {
+	var colunas []templateTypes.Colunas
+	if err := json.Unmarshal(template.Colunas, &colunas); err != nil {
+		return mapping{}, fmt.Errorf("%w: colunas mal formatadas: %v", ErrInvalidTemplate, err)
+	}
+
+	return buildMapping(ctx, template.Tabela, template.ColunaIdentificacao, colunas)
+}
+
+// ValidateTemplate confere o template com a estrutura atual do banco antes de salvá-lo
+func ValidateTemplate(ctx context.Context, tabela, colunaIdentificacao string, colunas []templateTypes.Colunas) error {
+	_, err := buildMapping(ctx, tabela, colunaIdentificacao, colunas)
+	return err
+}
+
+// buildMapping valida o template: a tabela precisa estar liberada em IMPORT_ALLOWED_TABLES,
+// as colunas informadas precisam existir nela (conforme o information_schema), a coluna de
+// identificação precisa estar mapeada e as regras de conversão precisam combinar com o tipo da coluna
+func buildMapping(ctx context.Context, tabela, colunaIdentificacao string, colunas []templateTypes.Colunas) (mapping, error) {
+	if !tableAllowed(tabela) {
+		return mapping{}, fmt.Errorf("%w: a tabela %q não está liberada para importação", ErrInvalidTemplate, tabela)
+	}
+	if len(colunas) == 0 {
+		return mapping{}, fmt.Errorf("%w: nenhuma coluna mapeada", ErrInvalidTemplate)
+	}
+
+	tableColumns, err := templateRepository.GetTableColumns(ctx, tabela)
+	if err != nil {
+		return mapping{}, err
+	}
+	if len(tableColumns) == 0 {
+		return mapping{}, fmt.Errorf("%w: a tabela %q não existe", ErrInvalidTemplate, tabela)
+	}
+
+	types := make(map[string]string, len(tableColumns))
+	for _, col := range tableColumns {
+		types[col.ColumnName] = col.DataType
+	}
+
+	return newMapping(tabela, colunaIdentificacao, colunas, types)
+}
+
+// newMapping confere as colunas do template com os tipos das colunas da tabela (nome -> data_type)
+func newMapping(tabela, colunaIdentificacao string, colunas []templateTypes.Colunas, types map[string]string) (mapping, error) {
+	m := mapping{Table: tabela, Identification: colunaIdentificacao, key: -1}
+	seen := make(map[string]bool, len(colunas))
+	for _, coluna := range colunas {
+		dataType, ok := types[coluna.Sistema]
+		if !ok {
+			return mapping{}, fmt.Errorf("%w: a coluna %q não existe na tabela %q", ErrInvalidTemplate, coluna.Sistema, tabela)
+		}
+		if coluna.Planilha < 1 {
+			return mapping{}, fmt.Errorf("%w: a coluna %q precisa indicar a posição na planilha (a partir de 1)", ErrInvalidTemplate, coluna.Sistema)
+		}
+		if seen[coluna.Sistema] {
+			return mapping{}, fmt.Errorf("%w: a coluna %q foi mapeada mais de uma vez", ErrInvalidTemplate, coluna.Sistema)
+		}
+		seen[coluna.Sistema] = true
+
+		col, err := newColumn(coluna, dataType)
+		if err != nil {
+			return mapping{}, fmt.Errorf("%w: coluna %q: %v", ErrInvalidTemplate, coluna.Sistema, err)
+		}
+
+		if coluna.Sistema == colunaIdentificacao {
+			m.key = len(m.Columns)
+		}
+		m.Columns = append(m.Columns, col)
+	}
+
+	if m.key < 0 {
+		return mapping{}, fmt.Errorf("%w: a coluna de identificação %q não está mapeada", ErrInvalidTemplate, colunaIdentificacao)
+	}
+
+	return m, nil
+}
+
+// newColumn valida as regras de conversão da coluna conforme o tipo de destino
+func newColumn(coluna templateTypes.Colunas, dataType string) (column, error) {
+	col := column{
+		Index:      coluna.Planilha - 1,
+		Name:       coluna.Sistema,
+		DataType:   dataType,
+		Decimal:    coluna.Decimal,
+		Verdadeiro: coluna.Verdadeiro,
+		Falso:      coluna.Falso,
+	}
+
+	if coluna.Formato != "" {
+		if !isDateType(dataType) {
+			return column{}, errors.New("formato só se aplica a colunas de data")
+		}
+		layout, err := dateLayout(coluna.Formato)
+		if err != nil {
+			return column{}, err
+		}
+		col.Layout = layout
+	}
+
+	if coluna.Decimal != "" {
+		if !isNumericType(dataType) {
+			return column{}, errors.New("decimal só se aplica a colunas numéricas")
+		}
+		if coluna.Decimal != "," && coluna.Decimal != "." {
+			return column{}, errors.New(`decimal deve ser "," ou "."`)
+		}
+	}
+
+	if len(coluna.Verdadeiro) > 0 || len(coluna.Falso) > 0 {
+		if dataType != "boolean" {
+			return column{}, errors.New("verdadeiro e falso só se aplicam a colunas lógicas")
+		}
+		if len(coluna.Verdadeiro) == 0 || len(coluna.Falso) == 0 {
+			return column{}, errors.New("informe os valores de verdadeiro e de falso")
+		}
+	}
+
+	return col, nil
+}
+
+// tableAllowed indica se a tabela pode receber importações (IMPORT_ALLOWED_TABLES,
+// lista separada por vírgulas; padrão shipments). Impede que um template grave em
+// tabelas do sistema, como users.
+func tableAllowed(table string) bool {
+	return slices.Contains(appConfig.Get().Import.AllowedTables, table)
+}
@@ -0,0 +1,131 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"sixTask/config/appConfig"
+	"sixTask/internal/types/templateTypes"
+)
+
+func TestBuildMappingRejectsBeforeReadingTheSchema(t *testing.T) {
+	appConfig.Set(&appConfig.Config{Import: appConfig.ImportConfig{AllowedTables: []string{"shipments"}}})
+
+	tests := []struct {
+		name    string
+		tabela  string
+		colunas []templateTypes.Colunas
+	}{
+		{"tabela não liberada", "users", []templateTypes.Colunas{{Planilha: 1, Sistema: "email"}}},
+		{"tabela vazia", "", []templateTypes.Colunas{{Planilha: 1, Sistema: "code"}}},
+		{"sem colunas", "shipments", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildMapping(context.Background(), tt.tabela, "code", tt.colunas)
+			if !errors.Is(err, ErrInvalidTemplate) {
+				t.Fatalf("buildMapping() erro = %v, esperado ErrInvalidTemplate", err)
+			}
+		})
+	}
+}
+
+func TestNewMapping(t *testing.T) {
+	types := map[string]string{
+		"code":       "character varying",
+		"weight":     "numeric",
+		"quantity":   "integer",
+		"shipped_at": "timestamp with time zone",
+		"delivered":  "boolean",
+	}
+
+	tests := []struct {
+		name           string
+		identification string
+		colunas        []templateTypes.Colunas
+		wantErr        bool
+	}{
+		{"template válido", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "weight", Decimal: ","},
+			{Planilha: 3, Sistema: "shipped_at", Formato: "dd/mm/aaaa hh:mi"},
+			{Planilha: 4, Sistema: "delivered", Verdadeiro: []string{"Entregue"}, Falso: []string{"Pendente"}},
+		}, false},
+		{"coluna inexistente", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "password"},
+		}, true},
+		{"posição na planilha ausente", "code", []templateTypes.Colunas{
+			{Planilha: 0, Sistema: "code"},
+		}, true},
+		{"coluna repetida", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "code"},
+		}, true},
+		{"identificação não mapeada", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "weight"},
+		}, true},
+		{"formato em coluna que não é data", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code", Formato: "dd/mm/aaaa"},
+		}, true},
+		{"formato sem ano", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "shipped_at", Formato: "dd/mm"},
+		}, true},
+		{"decimal em coluna de texto", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code", Decimal: ","},
+		}, true},
+		{"decimal inválido", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "quantity", Decimal: ";"},
+		}, true},
+		{"verdadeiro em coluna que não é lógica", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "quantity", Verdadeiro: []string{"sim"}, Falso: []string{"não"}},
+		}, true},
+		{"verdadeiro sem falso", "code", []templateTypes.Colunas{
+			{Planilha: 1, Sistema: "code"},
+			{Planilha: 2, Sistema: "delivered", Verdadeiro: []string{"Entregue"}},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newMapping("shipments", tt.identification, tt.colunas, types)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTemplate) {
+					t.Fatalf("newMapping() erro = %v, esperado ErrInvalidTemplate", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newMapping() erro inesperado: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewMappingColumns(t *testing.T) {
+	types := map[string]string{"code": "text", "shipped_at": "date"}
+
+	m, err := newMapping("shipments", "code", []templateTypes.Colunas{
+		{Planilha: 3, Sistema: "shipped_at", Formato: "dd/mm/aaaa"},
+		{Planilha: 1, Sistema: "code"},
+	}, types)
+	if err != nil {
+		t.Fatalf("newMapping() erro inesperado: %v", err)
+	}
+
+	if m.key != 1 {
+		t.Errorf("key = %d, esperado 1", m.key)
+	}
+	if names := m.Names(); !slices.Equal(names, []string{"shipped_at", "code"}) {
+		t.Errorf("Names() = %v", names)
+	}
+	if col := m.Columns[0]; col.Index != 2 || col.DataType != "date" || col.Layout != "02/01/2006" {
+		t.Errorf("Columns[0] = %+v", col)
+	}
+}
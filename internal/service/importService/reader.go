@@ -0,0 +1,136 @@
+package importService
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formatos de planilha aceitos, pela extensão do arquivo
+const (
+	formatXLSX = ".xlsx"
+	formatCSV  = ".csv"
+)
+
+// ErrInvalidSpreadsheet indica um arquivo que não pôde ser lido como planilha
+var ErrInvalidSpreadsheet = errors.New("planilha inválida")
+
+// readRows percorre as linhas de dados da planilha, ignorando o cabeçalho (primeira linha)
+// e linhas vazias. line é o número da linha na planilha, começando em 1.
+func readRows(r io.Reader, format string, fn func(line int, cells []string) error) error {
+	if format == formatCSV {
+		return readCSV(r, fn)
+	}
+
+	return readXLSX(r, fn)
+}
+
+// readXLSX lê a primeira aba da planilha. Os valores são lidos sem a formatação da célula,
+// de modo que números e datas chegam como no arquivo (datas como número de série do Excel).
+func readXLSX(r io.Reader, fn func(line int, cells []string) error) error {
+	f, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return fmt.Errorf("%w: nenhuma aba encontrada", ErrInvalidSpreadsheet)
+	}
+
+	rows, err := f.Rows(sheets[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	defer rows.Close()
+
+	line := 0
+	for rows.Next() {
+		line++
+		cells, err := rows.Columns()
+		if err != nil {
+			return fmt.Errorf("%w: linha %d: %v", ErrInvalidSpreadsheet, line, err)
+		}
+		if line == 1 || isEmptyRow(cells) {
+			continue
+		}
+		if err := fn(line, cells); err != nil {
+			return err
+		}
+	}
+
+	return rows.Error()
+}
+
+// readCSV lê o arquivo CSV detectando o separador (vírgula, ponto e vírgula ou tabulação)
+// pela primeira linha. O BOM gravado pelo Excel é ignorado.
+func readCSV(r io.Reader, fn func(line int, cells []string) error) error {
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		buffered.Discard(3)
+	}
+
+	header, _ := buffered.Peek(4096)
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(header)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	first := true
+	for {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+		}
+
+		if first {
+			first = false
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isEmptyRow(cells) {
+			continue
+		}
+		if err := fn(line, cells); err != nil {
+			return err
+		}
+	}
+}
+
+// detectDelimiter escolhe o separador mais frequente na primeira linha do arquivo
+func detectDelimiter(sample []byte) rune {
+	if i := bytes.IndexByte(sample, '\n'); i >= 0 {
+		sample = sample[:i]
+	}
+
+	delimiter, count := ',', strings.Count(string(sample), ",")
+	for _, candidate := range []rune{';', '\t'} {
+		if n := strings.Count(string(sample), string(candidate)); n > count {
+			delimiter, count = candidate, n
+		}
+	}
+
+	return delimiter
+}
+
+// isEmptyRow indica se todas as células da linha estão em branco
+func isEmptyRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
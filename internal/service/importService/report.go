@@ -0,0 +1,84 @@
+package importService
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"sixTask/config/storageProvider"
+)
+
+// errorReport grava os erros por linha em um CSV temporário, enviado ao storage ao final
+// da importação. O arquivo em disco evita manter milhares de erros em memória.
+type errorReport struct {
+	file   *os.File
+	writer *csv.Writer
+	count  int
+}
+
+// newErrorReport cria o relatório com o cabeçalho linha, coluna, valor e erro.
+// O BOM faz o Excel abrir o arquivo como UTF-8.
+func newErrorReport() (*errorReport, error) {
+	file, err := os.CreateTemp("", "import-errors-*.csv")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar relatório de erros: %w", err)
+	}
+
+	report := &errorReport{file: file, writer: csv.NewWriter(file)}
+	if _, err := file.WriteString("\ufeff"); err != nil {
+		report.Close()
+		return nil, err
+	}
+	if err := report.writer.Write([]string{"linha", "coluna", "valor", "erro"}); err != nil {
+		report.Close()
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Add registra o erro de uma linha da planilha; coluna e valor podem ficar vazios
+// quando o erro se refere à linha inteira
+func (r *errorReport) Add(line int, column, value, message string) error {
+	r.count++
+	return r.writer.Write([]string{strconv.Itoa(line), column, value, message})
+}
+
+// Len retorna a quantidade de erros registrados
+func (r *errorReport) Len() int {
+	return r.count
+}
+
+// Save envia o relatório para o storage na chave informada
+func (r *errorReport) Save(ctx context.Context, store storageProvider.Storage, key string) error {
+	r.writer.Flush()
+	if err := r.writer.Error(); err != nil {
+		return err
+	}
+
+	size, err := r.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := store.Put(ctx, key, r.file, size, "text/csv; charset=utf-8"); err != nil {
+		return fmt.Errorf("erro ao gravar relatório de erros: %w", err)
+	}
+
+	return nil
+}
+
+// Close remove o arquivo temporário
+func (r *errorReport) Close() {
+	r.file.Close()
+	if err := os.Remove(r.file.Name()); err != nil {
+		slog.Error("Erro ao remover relatório temporário", "file", r.file.Name(), "error", err)
+	}
+}
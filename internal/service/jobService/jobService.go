@@ -0,0 +1,104 @@
+package jobService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"sixTask/config/queue"
+)
+
+// ErrJobNotFound indica que o job não existe ou já expirou
+var ErrJobNotFound = errors.New("job não encontrado")
+
+// Status é a situação de um job no asynq, com o andamento informado pelo próprio job
+type Status struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Queue string `json:"queue"`
+	// State é pending, scheduled, active, retry, archived ou completed
+	State    string          `json:"state"`
+	Progress int             `json:"progress"`
+	Message  string          `json:"message,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Retried  int             `json:"retried"`
+	MaxRetry int             `json:"max_retry"`
+	// NextProcessAt é quando o job será executado (pendente, agendado ou aguardando nova tentativa)
+	NextProcessAt *time.Time `json:"next_process_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	// RequestedBy é quem disparou o job, usado na verificação de acesso
+	RequestedBy int64 `json:"-"`
+}
+
+// GetStatus procura o job em todas as filas e devolve a sua situação
+func GetStatus(ctx context.Context, id string) (Status, error) {
+	inspector := queue.Inspector()
+	defer inspector.Close()
+
+	queues, err := inspector.Queues()
+	if err != nil {
+		return Status{}, err
+	}
+
+	for _, name := range queues {
+		info, err := inspector.GetTaskInfo(name, id)
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			continue
+		}
+		if err != nil {
+			return Status{}, err
+		}
+
+		return fromTaskInfo(info), nil
+	}
+
+	return Status{}, ErrJobNotFound
+}
+
+// fromTaskInfo converte as informações do asynq, lendo o andamento gravado por queue.ReportProgress
+func fromTaskInfo(info *asynq.TaskInfo) Status {
+	status := Status{
+		ID:       info.ID,
+		Type:     info.Type,
+		Queue:    info.Queue,
+		State:    info.State.String(),
+		Error:    info.LastErr,
+		Retried:  info.Retried,
+		MaxRetry: info.MaxRetry,
+	}
+
+	var progress struct {
+		Percent int             `json:"percent"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if len(info.Result) > 0 && json.Unmarshal(info.Result, &progress) == nil {
+		status.Progress = progress.Percent
+		status.Message = progress.Message
+		status.Result = progress.Result
+	}
+
+	var requester queue.Requester
+	if json.Unmarshal(info.Payload, &requester) == nil {
+		status.RequestedBy = requester.RequestedBy
+	}
+
+	switch info.State {
+	case asynq.TaskStateCompleted:
+		status.Progress = 100
+		status.Error = ""
+		if !info.CompletedAt.IsZero() {
+			status.CompletedAt = &info.CompletedAt
+		}
+	case asynq.TaskStatePending, asynq.TaskStateScheduled, asynq.TaskStateRetry:
+		if !info.NextProcessAt.IsZero() {
+			status.NextProcessAt = &info.NextProcessAt
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,8 @@
+package attachmentStatusTypes
+
+// Situação do anexo quanto à verificação antivírus; apenas anexos Clean podem ser baixados
+const (
+	Pending     = "pending"
+	Clean       = "clean"
+	Quarantined = "quarantined"
+)
@@ -0,0 +1,9 @@
+package exportStatusTypes
+
+// Situação de uma exportação gerada pelo worker; apenas exportações Completed podem ser baixadas
+const (
+	Pending    = "pending"
+	Processing = "processing"
+	Completed  = "completed"
+	Failed     = "failed"
+)
@@ -0,0 +1,26 @@
+package exportTypes
+
+// Dados que podem ser exportados
+const (
+	Tasks     = "tasks"
+	Projects  = "projects"
+	Shipments = "shipments"
+)
+
+// Formatos de arquivo das exportações
+const (
+	XLSX = "xlsx"
+	CSV  = "csv"
+)
+
+// Filters são os filtros da exportação, os mesmos das rotas de listagem.
+// Campos vazios não filtram.
+type Filters struct {
+	ProjectID  int64  `json:"project_id,omitempty"`
+	AssignedTo int64  `json:"assigned_to,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	ClientID   int64  `json:"client_id,omitempty"`
+	UserID     int64  `json:"user_id,omitempty"`
+	ShipmentID string `json:"shipment_id,omitempty"`
+}
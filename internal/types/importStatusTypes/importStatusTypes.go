@@ -0,0 +1,9 @@
+package importStatusTypes
+
+// Situação de uma importação de planilha processada pelo worker
+const (
+	Pending    = "pending"
+	Processing = "processing"
+	Completed  = "completed"
+	Failed     = "failed"
+)
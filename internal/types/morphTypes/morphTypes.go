@@ -0,0 +1,10 @@
+package morphTypes
+
+// Tipos usados nas relações polimórficas (commentable, attachable e notifiable)
+const (
+	Project = "project"
+	Task    = "task"
+	Subtask = "subtask"
+	// Export é usado apenas como notifiable, nas notificações de exportação concluída
+	Export = "export"
+)
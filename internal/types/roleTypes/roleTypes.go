@@ -0,0 +1,13 @@
+package roleTypes
+
+// Papéis de usuário usados nas regras de autorização
+const (
+	Admin   = "admin"
+	Manager = "manager"
+	Member  = "member"
+)
+
+// IsValid informa se o papel informado é um dos papéis conhecidos
+func IsValid(role string) bool {
+	return role == Admin || role == Manager || role == Member
+}
@@ -1,6 +1,15 @@
 package templateTypes
 
+// Colunas relaciona uma coluna da planilha (Planilha, começando em 1) a uma coluna da tabela de destino (Sistema).
+// Os demais campos são regras opcionais de conversão, conforme o tipo da coluna de destino.
 type Colunas struct {
-	Planilha int
-	Sistema  string
+	Planilha int    `json:"planilha" binding:"gte=1"`
+	Sistema  string `json:"sistema" binding:"required,max=255"`
+	// Formato das datas na planilha, com dd, mm, aaaa, aa, hh, mi e ss (ex.: dd/mm/aaaa)
+	Formato string `json:"formato,omitempty" binding:"omitempty,max=50"`
+	// Separador decimal dos números ("," ou "."); vazio aceita os dois formatos
+	Decimal string `json:"decimal,omitempty"`
+	// Valores aceitos como verdadeiro e falso; vazio usa sim/não, true/false e 1/0
+	Verdadeiro []string `json:"verdadeiro,omitempty"`
+	Falso      []string `json:"falso,omitempty"`
 }
@@ -0,0 +1,7 @@
+package tokenTypes
+
+// Tipos de tokens de uso único enviados por email
+const (
+	PasswordReset     = "password_reset"
+	EmailVerification = "email_verification"
+)
@@ -1,65 +1,131 @@
 package routes
 
 import (
+	"log/slog"
+
 	"github.com/gin-gonic/gin"
-	"github.com/hibiken/asynq"
 	"github.com/hibiken/asynqmon"
-	"sixTask/internal/http/handler"
+	"sixTask/config/appConfig"
+	"sixTask/config/queue"
+	"sixTask/internal/http/apperror"
 	"sixTask/internal/http/handler/JobHandler"
+	accounthandler "sixTask/internal/http/handler/accountHandler"
 	attachmenthandler "sixTask/internal/http/handler/attachmentHandler"
 	authhandler "sixTask/internal/http/handler/authHandler"
 	clienthandler "sixTask/internal/http/handler/clientHandler"
 	commenthandler "sixTask/internal/http/handler/commentHandler"
+	exporthandler "sixTask/internal/http/handler/exportHandler"
 	filehandler "sixTask/internal/http/handler/fileHandler"
+	importhandler "sixTask/internal/http/handler/importHandler"
+	importtemplatehandler "sixTask/internal/http/handler/importTemplateHandler"
+	"sixTask/internal/http/handler/mailPreviewHandler"
 	notificationhandler "sixTask/internal/http/handler/notificationHandler"
 	projecthandler "sixTask/internal/http/handler/projectHandler"
 	subtaskhandler "sixTask/internal/http/handler/subtaskHandler"
 	taskhandler "sixTask/internal/http/handler/taskHandler"
+	uploadhandler "sixTask/internal/http/handler/uploadHandler"
 	userhandler "sixTask/internal/http/handler/userHandler"
 	"sixTask/internal/http/validator"
 	authmiddleware "sixTask/internal/middleware/authMiddleware"
+	errormiddleware "sixTask/internal/middleware/errorMiddleware"
+	logmiddleware "sixTask/internal/middleware/logMiddleware"
+	metricsmiddleware "sixTask/internal/middleware/metricsMiddleware"
+	tracemiddleware "sixTask/internal/middleware/traceMiddleware"
+	uploadmiddleware "sixTask/internal/middleware/uploadMiddleware"
+	"sixTask/internal/service/attachmentService"
 )
 
+// newRouter cria o roteador com os middlewares comuns ao servidor e ao worker
+func newRouter() *gin.Engine {
+	// Fora do ambiente local o gin não imprime avisos de depuração; as rotas registradas vão para o log em nível debug
+	if appConfig.Get().App.Env != "local" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	gin.DebugPrintRouteFunc = func(method, path, handler string, handlers int) {
+		slog.Debug("Rota registrada", "method", method, "path", path, "handler", handler)
+	}
+
+	// Cada requisição abre um span, que envolve os demais middlewares. Os logs das requisições e dos
+	// panics passam pelo slog, com o request_id de cada requisição, e a duração de cada uma é
+	// registrada por rota em /metrics. Os erros registrados com c.Error pelos handlers são
+	// respondidos no formato único da API (apperror.Problem).
+	router := gin.New()
+	router.Use(
+		tracemiddleware.Trace(appConfig.Get().App.Name),
+		logmiddleware.RequestID(),
+		logmiddleware.Logger(),
+		logmiddleware.Recovery(),
+		metricsmiddleware.Metrics(),
+		errormiddleware.Errors(),
+	)
+
+	// Rotas inexistentes também respondem no formato de erro da API
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(apperror.NotFound("Rota não encontrada"))
+	})
+
+	// Saúde, prontidão e métricas, fora do prefixo /api
+	registerProbes(router)
+
+	return router
+}
+
 func SetupRoutes() *gin.Engine {
-	router := gin.Default()
+	router := newRouter()
 
 	// Inicializa o validador com traduções em português
 	validator.InitValidator()
 
-	// Configurar o tamanho máximo de upload para 1GB
-	router.MaxMultipartMemory = 1 << 30 // 1GB
+	// Partes do multipart acima de 32MB vão para arquivos temporários em vez da memória.
+	// O tamanho máximo de cada upload é limitado por uploadmiddleware.LimitBody e pelas políticas de upload.
+	router.MaxMultipartMemory = 32 << 20 // 32MB
 
-	redisOpt := asynq.RedisClientOpt{Addr: "localhost:6379"}
 	monitor := asynqmon.New(asynqmon.Options{
 		RootPath:     "/monitor",
-		RedisConnOpt: redisOpt,
+		RedisConnOpt: queue.RedisOpt(),
 	})
 
-	router.Any("/monitor/*path", gin.WrapH(monitor))
+	// O painel expõe os payloads e permite reprocessar ou apagar tarefas: apenas administradores
+	router.Any("/monitor/*path", authmiddleware.AuthMiddleware(), authmiddleware.RequireAdmin(), gin.WrapH(monitor))
 
-	// Storage file serving route
+	// Storage file serving route (apenas links assinados gerados por Storage.URL)
 	router.GET("/storage/*filepath", filehandler.GetFileHandler())
 
 	api := router.Group("/api")
 	{
 		// Rotas públicas
-		api.POST("disparar-job", JobHandler.DisparJob)
-		api.POST("/", handler.StartHandler)
 		api.POST("/login", authhandler.Login)
-		api.POST("/upload", filehandler.UploadFileExample)
-
-		// Rotas de usuário
-		api.GET("/users", userhandler.GetUsers)
-		api.GET("/users/:id", userhandler.GetUser)
-		api.POST("/users", userhandler.CreateUser)
-		api.PUT("/users/:id", userhandler.UpdateUser)
-		api.DELETE("/users/:id", userhandler.DeleteUser)
+		api.POST("/refresh", authhandler.Refresh)
+		api.POST("/logout", authhandler.Logout)
+		api.POST("/password/forgot", accounthandler.ForgotPassword)
+		api.POST("/password/reset", accounthandler.ResetPassword)
+		api.POST("/email/verify", accounthandler.VerifyEmail)
+		api.GET("/files/attachments/:id", attachmenthandler.DownloadSignedAttachment)
+
+		// Prévia dos templates de email, disponível apenas fora do modo release
+		if gin.Mode() != gin.ReleaseMode {
+			api.GET("/dev/mail", mailPreviewHandler.ListTemplates)
+			api.GET("/dev/mail/:template", mailPreviewHandler.PreviewTemplate)
+		}
 
 		// Rotas autenticadas
 		authenticated := api.Group("/")
 		authenticated.Use(authmiddleware.AuthMiddleware())
+		limitUpload := uploadmiddleware.LimitBody(uploadmiddleware.MaxRequestSize())
 		{
 			authenticated.GET("/profile", authhandler.Profile)
+			authenticated.POST("/email/verification-notification", accounthandler.ResendVerification)
+
+			// Rotas de jobs: disparo do job de exemplo e acompanhamento de qualquer job pelo ID
+			authenticated.POST("/disparar-job", JobHandler.DisparJob)
+			authenticated.GET("/jobs/:id", JobHandler.GetJob)
+
+			// Rotas de usuário (apenas administradores)
+			authenticated.GET("/users", userhandler.GetUsers)
+			authenticated.GET("/users/:id", userhandler.GetUser)
+			authenticated.POST("/users", userhandler.CreateUser)
+			authenticated.PUT("/users/:id", userhandler.UpdateUser)
+			authenticated.DELETE("/users/:id", userhandler.DeleteUser)
 
 			// Rotas de cliente
 			authenticated.GET("/clients", clienthandler.GetClients)
@@ -73,6 +139,7 @@ func SetupRoutes() *gin.Engine {
 			authenticated.GET("/projects/:id", projecthandler.GetProject)
 			authenticated.GET("/projects/by-client/:client_id", projecthandler.GetProjectsByClient)
 			authenticated.GET("/projects/by-user/:user_id", projecthandler.GetProjectsByUser)
+			authenticated.POST("/projects/export", exporthandler.ExportProjects)
 			authenticated.POST("/projects", projecthandler.CreateProject)
 			authenticated.PUT("/projects/:id", projecthandler.UpdateProject)
 			authenticated.DELETE("/projects/:id", projecthandler.DeleteProject)
@@ -84,6 +151,7 @@ func SetupRoutes() *gin.Engine {
 			authenticated.GET("/tasks/by-user/:user_id", taskhandler.GetTasksByAssignedTo)
 			authenticated.GET("/tasks/by-status/:status", taskhandler.GetTasksByStatus)
 			authenticated.GET("/tasks/by-priority/:priority", taskhandler.GetTasksByPriority)
+			authenticated.POST("/tasks/export", exporthandler.ExportTasks)
 			authenticated.POST("/tasks", taskhandler.CreateTask)
 			authenticated.PUT("/tasks/:id", taskhandler.UpdateTask)
 			authenticated.PUT("/tasks/:id/complete", taskhandler.CompleteTask)
@@ -112,12 +180,43 @@ func SetupRoutes() *gin.Engine {
 			// Rotas de anexo
 			authenticated.GET("/attachments", attachmenthandler.GetAttachments)
 			authenticated.GET("/attachments/:id", attachmenthandler.GetAttachment)
+			authenticated.GET("/attachments/:id/download", attachmenthandler.DownloadAttachment)
+			authenticated.GET("/attachments/:id/signed-url", attachmenthandler.GetAttachmentSignedURL)
+			authenticated.GET("/attachments/:id/thumbnail", attachmenthandler.GetAttachmentThumbnail)
 			authenticated.GET("/attachments/user/:user_id", attachmenthandler.GetAttachmentsByUser)
 			authenticated.GET("/attachments/by-attachable/:attachable_type/:attachable_id", attachmenthandler.GetAttachmentsByAttachable)
-			authenticated.POST("/attachments", attachmenthandler.CreateAttachment)
+			authenticated.POST("/attachments", limitUpload, attachmenthandler.CreateAttachment)
 			authenticated.PUT("/attachments/:id", attachmenthandler.UpdateAttachment)
 			authenticated.DELETE("/attachments/:id", attachmenthandler.DeleteAttachment)
 
+			// Rotas de upload em blocos (retomável), que ao final criam o anexo
+			authenticated.POST("/uploads", uploadhandler.CreateUpload)
+			authenticated.GET("/uploads/:id", uploadhandler.GetUpload)
+			authenticated.HEAD("/uploads/:id", uploadhandler.HeadUpload)
+			authenticated.PATCH("/uploads/:id", uploadmiddleware.LimitBody(attachmentService.MaxChunkSize()), uploadhandler.PatchUpload)
+			authenticated.POST("/uploads/:id/finish", uploadhandler.FinishUpload)
+			authenticated.DELETE("/uploads/:id", uploadhandler.DeleteUpload)
+
+			// Rotas de importação de planilhas, processadas pelo worker na fila planilhas
+			authenticated.GET("/imports", importhandler.GetImports)
+			authenticated.GET("/imports/:id", importhandler.GetImport)
+			authenticated.GET("/imports/:id/errors", importhandler.GetImportErrors)
+			authenticated.POST("/imports", limitUpload, importhandler.CreateImport)
+
+			// Rotas dos templates de importação
+			authenticated.GET("/import-templates", importtemplatehandler.GetTemplates)
+			authenticated.GET("/import-templates/:id", importtemplatehandler.GetTemplate)
+			authenticated.POST("/import-templates", importtemplatehandler.CreateTemplate)
+			authenticated.PUT("/import-templates/:id", importtemplatehandler.UpdateTemplate)
+			authenticated.DELETE("/import-templates/:id", importtemplatehandler.DeleteTemplate)
+			authenticated.POST("/import-templates/:id/dry-run", limitUpload, importtemplatehandler.DryRunTemplate)
+
+			// Rotas de exportação (XLSX ou CSV), geradas pelo worker na fila planilhas
+			authenticated.POST("/shipments/export", exporthandler.ExportShipments)
+			authenticated.GET("/exports", exporthandler.GetExports)
+			authenticated.GET("/exports/:id", exporthandler.GetExport)
+			authenticated.GET("/exports/:id/download", exporthandler.DownloadExport)
+
 			// Rotas de notificação
 			authenticated.GET("/notifications", notificationhandler.GetNotifications)
 			authenticated.GET("/notifications/:id", notificationhandler.GetNotification)
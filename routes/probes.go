@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	healthhandler "sixTask/internal/http/handler/healthHandler"
+)
+
+// registerProbes registra as rotas consultadas pelo orquestrador e pelo Prometheus
+func registerProbes(router *gin.Engine) {
+	router.GET("/healthz", healthhandler.Healthz)
+	router.GET("/readyz", healthhandler.Readyz)
+	router.GET("/metrics", healthhandler.Metrics)
+}
+
+// SetupWorkerRoutes cria o servidor HTTP do worker, que expõe apenas /healthz, /readyz e /metrics
+func SetupWorkerRoutes() *gin.Engine {
+	return newRouter()
+}
@@ -0,0 +1,16 @@
+// Package template reúne os templates de email embutidos no binário.
+//
+// Estrutura:
+//
+//	layouts/  layout base compartilhado (base.html)
+//	partials/ trechos reutilizáveis (botão, rodapé)
+//	emails/<locale>/<nome>.html  corpo de cada email, por idioma
+//	emails/<locale>/<nome>.txt   versão texto opcional; sem ela o texto é gerado a partir do HTML
+package template
+
+import "embed"
+
+// FS contém os layouts, partials e emails
+//
+//go:embed layouts partials emails
+var FS embed.FS